@@ -2,148 +2,246 @@ package gen
 
 import (
 	"fmt"
-	"math/rand"
 	"reflect"
 	"strings"
 )
 
-var complexSize = 50
+// InferOption configures the generators created by Infer, wrapped generators (see Wrap) are InferOptions as well.
+type InferOption interface {
+	applyInfer(c *inferConfig)
+}
+
+type inferOptionFunc func(c *inferConfig)
+
+func (f inferOptionFunc) applyInfer(c *inferConfig) { f(c) }
+
+type inferConfig struct {
+	generatorsByType map[reflect.Type]*WrappedGen
+	strings          *stringGen
+	// tags holds the generators of the tagged fields of each struct type, by the index of the fields.
+	tags map[reflect.Type][]*fieldGen
+	// fields holds the generators bound to field paths, see Field.
+	fields   *fieldNode
+	bindings []fieldBinding
+	// deterministicFuncs makes the generated functions return the same outputs for the same inputs.
+	deterministicFuncs bool
+}
+
+// textRune is the default alphabet of the strings generated by Infer: all the runes which can be encoded in UTF-8,
+// but the control characters (including U+0000) and the noncharacters, which most text handling code rejects.
+var textRune = func() *runeClass {
+	ranges := []rune{0x20, 0x7e, 0xa0, 0xfdcf, 0xfdf0}
+	for plane := rune(0); plane <= 0x10; plane++ {
+		ranges = append(ranges, plane<<16|0xfffd, (plane+1)<<16)
+	}
+	return newRuneClass(ranges[:len(ranges)-1])
+}()
 
-func sizedValue(t reflect.Type, size int) (value reflect.Value, ok bool) {
+func newInferConfig(options []InferOption) *inferConfig {
+	c := &inferConfig{
+		generatorsByType: make(map[reflect.Type]*WrappedGen),
+		strings:          &stringGen{textRune, 0, defaultSize},
+		tags:             make(map[reflect.Type][]*fieldGen),
+		fields:           &fieldNode{},
+	}
+	for _, option := range options {
+		option.applyInfer(c)
+	}
+	return c
+}
+
+// InferStrings configures the strings generated by Infer, which are made of the runes of the given alphabet,
+// and are within the given length bounds. The length grows with the size, just like the length of the collections.
+// An empty alphabet stands for all the runes which can be encoded in UTF-8 (i.e., all but the surrogate halves),
+// except for the control characters (including U+0000) and the noncharacters (like U+FFFE),
+// which is the default, along with the length bounds of 0 and 50. The strings are always valid UTF-8.
+//
+//	personGen, err := gen.Infer[Person](gen.InferStrings(gen.AlphabetLetters, 1, 20))
+func InferStrings(alphabet string, minLength, maxLength uint) InferOption {
+	g := StringGen(alphabet, minLength, maxLength).(*stringGen)
+	if alphabet == "" {
+		g.alphabet = textRune
+	}
+	return inferOptionFunc(func(c *inferConfig) { c.strings = g })
+}
+
+// sizedValue generates a value of the given type, the node holds the generators which are bound to the paths
+// of the fields within the value (if any).
+// The values within pointers, slices and maps are given less size than their containers, and the containers are
+// nil or empty when there's no size left, so that the values of recursive types are finite.
+// It panics if the type cannot be inferred, which Infer checks beforehand (see inferrable).
+func (c *inferConfig) sizedValue(r *Rand, t reflect.Type, size int, node *fieldNode) reflect.Value {
+	if size < 0 {
+		size = 0
+	}
+	if wrapped, found := c.generatorsByType[t]; found {
+		return GenerateWith(wrapped.vg, r)
+	}
 	v := reflect.New(t).Elem()
 	switch concrete := t; concrete.Kind() {
 	case reflect.Bool:
-		v.SetBool(ArbitraryInt.Generate()&1 == 0)
+		v.SetBool(r.draw(1) == 1)
 	case reflect.Float32:
-		v.SetFloat(float64(ArbitraryFloat32.Generate()))
+		v.SetFloat(float64(GenerateWith(ArbitraryFloat32, r)))
 	case reflect.Float64:
-		v.SetFloat(ArbitraryFloat64.Generate())
+		v.SetFloat(GenerateWith(ArbitraryFloat64, r))
 	case reflect.Complex64:
-		v.SetComplex(complex(ArbitraryFloat64.Generate(), ArbitraryFloat64.Generate()))
+		v.SetComplex(complex128(GenerateWith(ArbitraryComplex64, r)))
 	case reflect.Complex128:
-		v.SetComplex(complex(ArbitraryFloat64.Generate(), ArbitraryFloat64.Generate()))
+		v.SetComplex(GenerateWith(ArbitraryComplex128, r))
 	case reflect.Int16:
-		v.SetInt(ArbitraryInt64.Generate())
+		v.SetInt(int64(GenerateWith(ArbitraryInt16, r)))
 	case reflect.Int32:
-		v.SetInt(ArbitraryInt64.Generate())
+		v.SetInt(int64(GenerateWith(ArbitraryInt32, r)))
 	case reflect.Int64:
-		v.SetInt(ArbitraryInt64.Generate())
+		v.SetInt(GenerateWith(ArbitraryInt64, r))
 	case reflect.Int8:
-		v.SetInt(ArbitraryInt64.Generate())
+		v.SetInt(int64(GenerateWith(ArbitraryInt8, r)))
 	case reflect.Int:
-		v.SetInt(ArbitraryInt64.Generate())
+		v.SetInt(int64(GenerateWith(ArbitraryInt, r)))
 	case reflect.Uint16:
-		v.SetUint(uint64(ArbitraryUint16.Generate()))
+		v.SetUint(uint64(GenerateWith(ArbitraryUint16, r)))
 	case reflect.Uint32:
-		v.SetUint(uint64(ArbitraryUint32.Generate()))
+		v.SetUint(uint64(GenerateWith(ArbitraryUint32, r)))
 	case reflect.Uint64:
-		v.SetUint(ArbitraryUint64.Generate())
+		v.SetUint(GenerateWith(ArbitraryUint64, r))
 	case reflect.Uint8:
-		v.SetUint(uint64(ArbitraryUint8.Generate()))
+		v.SetUint(uint64(GenerateWith(ArbitraryUint8, r)))
 	case reflect.Uint:
-		v.SetUint(uint64(ArbitraryUint.Generate()))
+		v.SetUint(uint64(GenerateWith(ArbitraryUint, r)))
 	case reflect.Uintptr:
-		v.SetUint(ArbitraryUint64.Generate())
+		v.SetUint(GenerateWith(ArbitraryUint64, r))
 	case reflect.Map:
-		numElems := Between(0, size).Generate()
+		numElems := GenerateWith(Between(0, size), r)
+		sizeLeft := size - numElems
 		v.Set(reflect.MakeMap(concrete))
 		for i := 0; i < numElems; i++ {
-			key, ok1 := sizedValue(concrete.Key(), size)
-			value, ok2 := sizedValue(concrete.Elem(), size)
-			if !ok1 || !ok2 {
-				return reflect.Value{}, false
-			}
-			v.SetMapIndex(key, value)
+			key := c.sizedValue(r, concrete.Key(), size, nil)
+			v.SetMapIndex(key, c.sizedValue(r, concrete.Elem(), sizeLeft, node))
 		}
 	case reflect.Pointer:
-		if Between(0, size).Generate() == 0 {
+		if GenerateWith(Between(0, size), r) == 0 {
 			v.Set(reflect.Zero(concrete)) // Generate nil pointer.
 		} else {
-			elem, ok := sizedValue(concrete.Elem(), size)
-			if !ok {
-				return reflect.Value{}, false
-			}
 			v.Set(reflect.New(concrete.Elem()))
-			v.Elem().Set(elem)
+			v.Elem().Set(c.sizedValue(r, concrete.Elem(), size-1, node))
 		}
 	case reflect.Slice:
-		numElems := Between(0, size).Generate()
+		numElems := GenerateWith(Between(0, size), r)
 		sizeLeft := size - numElems
 		v.Set(reflect.MakeSlice(concrete, numElems, numElems))
 		for i := 0; i < numElems; i++ {
-			elem, ok := sizedValue(concrete.Elem(), sizeLeft)
-			if !ok {
-				return reflect.Value{}, false
-			}
-			v.Index(i).Set(elem)
+			v.Index(i).Set(c.sizedValue(r, concrete.Elem(), sizeLeft, node))
 		}
 	case reflect.Array:
 		for i := 0; i < v.Len(); i++ {
-			elem, ok := sizedValue(concrete.Elem(), size)
-			if !ok {
-				return reflect.Value{}, false
-			}
-			v.Index(i).Set(elem)
+			v.Index(i).Set(c.sizedValue(r, concrete.Elem(), size, node))
 		}
 	case reflect.String:
-		numChars := rand.Intn(complexSize)
-		codePoints := make([]rune, numChars)
-		for i := 0; i < numChars; i++ {
-			codePoints[i] = rune(Between(0, 0x10ffff).Generate())
-		}
-		v.SetString(string(codePoints))
+		v.SetString(c.strings.sized(r, size))
+	case reflect.Func:
+		v.Set(c.funcValue(r, concrete, size-1))
 	case reflect.Struct:
 		n := v.NumField()
-		// Divide sizeLeft evenly among the struct fields.
+		// Divide sizeLeft evenly among the struct fields, the fields are given no size only if there's none left.
 		sizeLeft := size
 		if n > sizeLeft {
-			sizeLeft = 1
+			sizeLeft = numericMin(sizeLeft, 1)
 		} else if n > 0 {
 			sizeLeft /= n
 		}
-		for i := 0; i < n; i++ {
-			elem, ok := sizedValue(concrete.Field(i).Type, sizeLeft)
-			if !ok {
-				return reflect.Value{}, false
+		c.fillStruct(r, v, sizeLeft, node)
+	default:
+		panic(notInferrable(t))
+	}
+
+	return v
+}
+
+// fillStruct generates the fields of the given (addressable) struct value, each of which with the given size.
+// Unexported fields are left as they are, except for the embedded structs, whose exported fields are promoted.
+func (c *inferConfig) fillStruct(r *Rand, v reflect.Value, size int, node *fieldNode) {
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			if field.Anonymous && field.Type.Kind() == reflect.Struct {
+				c.fillStruct(r, v.Field(i), size, node.child(field.Name))
 			}
-			v.Field(i).Set(elem)
+			continue
 		}
-	default:
-		return reflect.Value{}, false
+		v.Field(i).Set(c.fieldValue(r, t, i, size, node.child(field.Name)))
 	}
+}
 
-	return v, true
+// fieldValue generates a value for the i-th field of the given struct type, using the generator which is bound to
+// its path (see Field), or the one of its tag, if any.
+func (c *inferConfig) fieldValue(r *Rand, t reflect.Type, i, size int, node *fieldNode) reflect.Value {
+	if node.bound() {
+		return GenerateWith(node.gen.vg, r)
+	}
+	if tagged := c.tagged(t, i); tagged != nil {
+		return tagged.generate(r, size, node)
+	}
+	return c.sizedValue(r, t.Field(i).Type, size, node)
+}
+
+// fieldShrinker returns the shrinker of the i-th field of the given struct type, which is the shrinker of the generator
+// bound to its path, or the one of its tag, if any. It returns false if the field is not to be shrunk.
+func (c *inferConfig) fieldShrinker(t reflect.Type, i int, node *fieldNode) (func(reflect.Value) []reflect.Value, bool) {
+	if node.bound() {
+		return node.gen.shrink, node.gen.shrink != nil
+	}
+	if tagged := c.tagged(t, i); tagged != nil {
+		if tagged.shrink == nil {
+			return nil, false
+		}
+		return func(v reflect.Value) []reflect.Value { return tagged.shrink(v, node) }, true
+	}
+	return c.shrinkerAt(node), true
 }
 
 type adhocGen[T any] struct {
-	generatorsByType map[reflect.Type]*WrappedGen
+	config *inferConfig
 }
 
-func (g *adhocGen[T]) Generate() T {
+func (g *adhocGen[T]) Generate() T { return g.generate(defaultRand) }
+
+func (g *adhocGen[T]) generate(r *Rand) T {
 	actual := new(T)
-	tpe := reflect.TypeOf(actual)
 	v := reflect.ValueOf(actual).Elem()
+	// The fields of the top-level structs are given the whole size, rather than dividing it among them.
+	if _, found := g.config.generatorsByType[v.Type()]; v.Kind() != reflect.Struct || found {
+		v.Set(g.config.sizedValue(r, v.Type(), r.size, g.config.fields))
+	} else {
+		g.config.fillStruct(r, v, r.size, g.config.fields)
+	}
+	return *actual
+}
 
-	concrete := tpe.Elem()
-
-	for i := 0; i < concrete.NumField(); i++ {
-		fieldType := concrete.Field(i)
-		if g, found := g.generatorsByType[fieldType.Type]; found {
-			if v.Field(i).CanSet() {
-				v.Field(i).Set(g.vg.Generate())
-				continue
-			}
-		} else {
-			if fieldValue, ok := sizedValue(fieldType.Type, complexSize); ok {
-				if v.Field(i).CanSet() {
-					v.Field(i).Set(fieldValue)
-				}
-			} else {
-				panic(notInferrable(fieldType.Type))
-			}
+// Shrink shrinks the fields of the given value, using the shrinkers of the generators that are registered for their types
+// (or bound to their paths, or the ones of their tags), or reflectively if there are no generators registered for them.
+func (g *adhocGen[T]) Shrink(value T) []T {
+	v := reflect.ValueOf(&value).Elem()
+	var candidates []T
+	if _, found := g.config.generatorsByType[v.Type()]; v.Kind() != reflect.Struct || found {
+		for _, candidate := range g.config.shrinkValue(v, g.config.fields) {
+			var shrunk T
+			reflect.ValueOf(&shrunk).Elem().Set(candidate)
+			candidates = append(candidates, shrunk)
 		}
+		return candidates
 	}
-	return *actual
+	for i := 0; i < v.NumField(); i++ {
+		shrinker, ok := g.config.fieldShrinker(v.Type(), i, g.config.fields.child(v.Type().Field(i).Name))
+		if !ok {
+			continue
+		}
+		for _, candidate := range shrinkField(v, i, shrinker) {
+			candidates = append(candidates, candidate.Interface().(T))
+		}
+	}
+	return candidates
 }
 
 func getFunctionSignature(ft reflect.Type) string {
@@ -168,34 +266,81 @@ func getFunctionSignature(ft reflect.Type) string {
 }
 
 func notInferrable(t reflect.Type) error {
+	return fmt.Errorf("cannot infer `%s` yet", t)
+}
+
+// inferrable checks that the values of the given type can be generated, given the generators bound to the paths
+// of the fields within it (held by the node), and returns the error of the first type within it which cannot be.
+// The types are checked once, which makes the recursive types terminate, unless there are generators bound within them.
+func (c *inferConfig) inferrable(t reflect.Type, node *fieldNode, checked map[reflect.Type]bool) error {
+	if _, found := c.generatorsByType[t]; found {
+		return nil
+	}
+	if node == nil {
+		if checked[t] {
+			return nil
+		}
+		checked[t] = true
+	}
 	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return nil
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return c.inferrable(t.Elem(), node, checked)
+	case reflect.Map:
+		if err := c.inferrable(t.Key(), nil, checked); err != nil {
+			return err
+		}
+		return c.inferrable(t.Elem(), node, checked)
 	case reflect.Func:
-		return fmt.Errorf("cannot infer functions yet: %s", getFunctionSignature(t))
+		for i := 0; i < t.NumOut(); i++ {
+			if err := c.inferrable(t.Out(i), nil, checked); err != nil {
+				return fmt.Errorf("cannot infer the outputs of the function %s: %w", getFunctionSignature(t), err)
+			}
+		}
+		return nil
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			field, child := t.Field(i), node.child(t.Field(i).Name)
+			// The unexported fields are not generated, except for the exported fields of the embedded structs.
+			if !field.IsExported() && !(field.Anonymous && field.Type.Kind() == reflect.Struct) || child.bound() {
+				continue
+			}
+			if tagged := c.tagged(t, i); tagged != nil && !tagged.inferred {
+				continue
+			}
+			if err := c.inferrable(field.Type, child, checked); err != nil {
+				return err
+			}
+		}
+		return nil
 	default:
-		return fmt.Errorf("cannot infer `%s` yet", t)
+		return notInferrable(t)
 	}
 }
 
-// Infer can infer generators for the given type parameter `T`, using the given wrapped generators.
-// In case if the type T contains functions or types that gen's adhoc does not currently support,
-// it returns an error, and if not, it returns the generator.
-func Infer[T any](valueGenerators ...*WrappedGen) (Gen[T], error) {
-	tpe := reflect.TypeOf(*new(T))
-
-	valueGeneratorsByType := make(map[reflect.Type]*WrappedGen)
-
-	for _, vg := range valueGenerators {
-		valueGeneratorsByType[vg.tpe] = vg
+// Infer can infer generators for the given type parameter `T`, using the given options, which are mostly wrapped generators
+// to be used for the values of their types (see Wrap).
+// T may be any type which is made of structs, pointers, slices, arrays, maps, functions, strings and numeric or boolean
+// values, including the recursive ones (e.g., trees), whose depth is bounded by the size.
+// The generated functions return generated outputs, see DeterministicFuncs for making them deterministic.
+// It returns an error if T contains types that gen's adhoc does not currently support (e.g., channels, or interfaces
+// which there are no wrapped generators for), or if the tags (see the gen struct tags) or the field paths (see Field)
+// are invalid.
+func Infer[T any](options ...InferOption) (Gen[T], error) {
+	tpe := reflect.TypeOf((*T)(nil)).Elem()
+	config := newInferConfig(options)
+	if err := config.prepareTags(tpe); err != nil {
+		return nil, err
 	}
-
-	for i := 0; i < tpe.NumField(); i++ {
-		if tpe.Field(i).Type.Kind() == reflect.Func {
-			return nil, notInferrable(tpe.Field(i).Type)
-		}
+	if err := config.bindFields(tpe); err != nil {
+		return nil, err
 	}
-
-	if tpe.Kind() == reflect.Func {
-		return nil, notInferrable(tpe)
+	if err := config.inferrable(tpe, config.fields, make(map[reflect.Type]bool)); err != nil {
+		return nil, err
 	}
-	return &adhocGen[T]{valueGeneratorsByType}, nil
+	return &adhocGen[T]{config}, nil
 }
@@ -0,0 +1,598 @@
+package gen
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+)
+
+type Document struct {
+	Title string
+	Tags  []string
+	Meta  map[string]string
+}
+
+func TestInferGeneratesValidUTF8(t *testing.T) {
+	g, err := Infer[Document]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, doc := range GenerateN(g, 100) {
+		if !utf8.ValidString(doc.Title) {
+			t.Fatalf("Infer generated invalid UTF-8: %q", doc.Title)
+		}
+		for _, c := range doc.Title {
+			if unicode.IsControl(c) || c&0xfffe == 0xfffe || (c >= 0xfdd0 && c <= 0xfdef) {
+				t.Fatalf("Infer generated a control character or a noncharacter: %q", doc.Title)
+			}
+		}
+		encoded, err := json.Marshal(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded Document
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if decoded.Title != doc.Title {
+			t.Fatalf("inferred string did not survive a JSON round-trip: %q became %q", doc.Title, decoded.Title)
+		}
+	}
+}
+
+func TestInferStrings(t *testing.T) {
+	g, err := Infer[Document](InferStrings(AlphabetLower, 2, 8))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check := func(s string) {
+		if len(s) < 2 || len(s) > 8 || strings.Trim(s, AlphabetLower) != "" {
+			t.Fatalf("Infer did not respect the configured strings: %q", s)
+		}
+	}
+	for _, doc := range GenerateN(g, 100) {
+		check(doc.Title)
+		for _, tag := range doc.Tags {
+			check(tag)
+		}
+	}
+}
+
+func TestInferredStringsRespectTheSize(t *testing.T) {
+	g, err := Infer[Document](InferStrings("", 1, 100))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, doc := range GenerateN(Resize(g, 0), 100) {
+		if utf8.RuneCountInString(doc.Title) != 1 {
+			t.Fatalf("Infer generated a string longer than its minimum length at size 0: %q", doc.Title)
+		}
+	}
+}
+
+func TestInferredStringsShrinkWithinTheirBounds(t *testing.T) {
+	g, err := Infer[Document](InferStrings("xyz", 3, 20))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkErr := Check(g, func(d Document) bool { return !strings.Contains(d.Title, "z") })
+
+	actual := counterexampleOf(t, checkErr).(Document)
+	if actual.Title != "xxz" && actual.Title != "zxx" && actual.Title != "xzx" {
+		t.Errorf("inferred string was not shrunk within its bounds, got: %q", actual.Title)
+	}
+}
+
+type Customer struct {
+	Age      int      `gen:"between=18,65"`
+	Color    string   `gen:"oneof=red|green|blue"`
+	Email    string   `gen:"regex=[a-z]{3,8}@example\\.com"`
+	Nickname string   `gen:"len=3..10"`
+	Tags     []string `gen:"len=1..3"`
+	Internal string   `gen:"-"`
+	Address  struct {
+		ZipCode string `gen:"regex=[0-9]{5}"`
+		Floor   uint8  `gen:"between=1,20"`
+	}
+}
+
+func TestInferHonorsStructTags(t *testing.T) {
+	g, err := Infer[Customer](InferStrings(AlphabetLower, 0, 20))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	email := regexp.MustCompile(`^[a-z]{3,8}@example\.com$`)
+	zipCode := regexp.MustCompile(`^[0-9]{5}$`)
+	for _, c := range GenerateN(g, 200) {
+		valid := isBetween(c.Age, 18, 65) &&
+			(c.Color == "red" || c.Color == "green" || c.Color == "blue") &&
+			email.MatchString(c.Email) &&
+			len(c.Nickname) >= 3 && len(c.Nickname) <= 10 &&
+			len(c.Tags) >= 1 && len(c.Tags) <= 3 &&
+			c.Internal == "" &&
+			zipCode.MatchString(c.Address.ZipCode) &&
+			c.Address.Floor >= 1 && c.Address.Floor <= 20
+		if !valid {
+			t.Fatalf("Infer did not honor the struct tags: %+v", c)
+		}
+	}
+}
+
+func TestInferTakesTagsOverWrappedGenerators(t *testing.T) {
+	g, err := Infer[Customer](Wrap(Only(-1)), Wrap(Only("wrapped")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range GenerateN(g, 100) {
+		if c.Age == -1 || c.Color == "wrapped" {
+			t.Fatalf("Infer used the wrapped generators for tagged fields: %+v", c)
+		}
+	}
+}
+
+func TestInferredTaggedFieldsShrinkWithinTheirConstraints(t *testing.T) {
+	g, err := Infer[Customer]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkErr := Check(g, func(c Customer) bool { return c.Age < 30 })
+
+	actual := counterexampleOf(t, checkErr).(Customer)
+	if actual.Age != 30 || actual.Color != "red" || len(actual.Nickname) != 3 || len(actual.Tags) != 1 || actual.Address.Floor != 1 {
+		t.Errorf("tagged fields were not shrunk within their constraints, got: %+v", actual)
+	}
+}
+
+func TestInferReturnsTagErrors(t *testing.T) {
+	type BadBetween struct {
+		Age int `gen:"between=18"`
+	}
+	type BadType struct {
+		Name string `gen:"between=1,2"`
+	}
+	type BadRegex struct {
+		Name string `gen:"regex=(a)\\1"`
+	}
+	type BadLen struct {
+		Age int `gen:"len=1..2"`
+	}
+	type BadKey struct {
+		Age int `gen:"unknown=1"`
+	}
+	type BadNested struct {
+		Inner []struct {
+			Value int `gen:"oneof=1|two"`
+		}
+	}
+
+	errs := []error{
+		inferErr[BadBetween](), inferErr[BadType](), inferErr[BadRegex](),
+		inferErr[BadLen](), inferErr[BadKey](), inferErr[BadNested](),
+	}
+	for i, err := range errs {
+		if err == nil || !strings.Contains(err.Error(), "invalid tag") {
+			t.Errorf("Infer did not return a tag error for case %d, got: %v", i, err)
+		}
+	}
+}
+
+func inferErr[T any]() error {
+	_, err := Infer[T]()
+	return err
+}
+
+type Address struct {
+	Street  string
+	ZipCode string
+}
+
+type Order struct {
+	Total    int
+	Shipping Address
+}
+
+type Account struct {
+	Name      string
+	Home      Address
+	Work      *Address
+	Orders    []Order
+	Addresses map[string]Address
+}
+
+func TestInferWithFieldPaths(t *testing.T) {
+	g, err := Infer[Account](
+		Wrap(Only("wrapped")),
+		Field("Name", OneOf("John", "Jack")),
+		Field("Home.ZipCode", MustRegex(`[0-9]{5}`)),
+		Field("Work.ZipCode", Only("work")),
+		Field("Orders.Total", Between(1, 10)),
+		Field("Orders.Shipping.Street", Only("shipping")),
+		Field("Addresses.ZipCode", Only("mapped")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zipCode := regexp.MustCompile(`^[0-9]{5}$`)
+	for _, account := range GenerateN(g, 100) {
+		if account.Name != "John" && account.Name != "Jack" {
+			t.Fatalf("the field path took no precedence over the wrapped generator: %q", account.Name)
+		}
+		if !zipCode.MatchString(account.Home.ZipCode) {
+			t.Fatalf("the generator of Home.ZipCode was not used: %q", account.Home.ZipCode)
+		}
+		if account.Work != nil && account.Work.ZipCode != "work" {
+			t.Fatalf("the generator of Work.ZipCode was not used: %q", account.Work.ZipCode)
+		}
+		for _, order := range account.Orders {
+			if !isBetween(order.Total, 1, 10) || order.Shipping.Street != "shipping" {
+				t.Fatalf("the generators of the orders were not used: %+v", order)
+			}
+		}
+		for _, address := range account.Addresses {
+			if address.ZipCode != "mapped" {
+				t.Fatalf("the generator of the map values was not used: %+v", address)
+			}
+		}
+	}
+}
+
+func TestInferredFieldPathsShrinkUsingTheirGenerators(t *testing.T) {
+	g, err := Infer[Account](Field("Orders.Total", Between(100, 999)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkErr := Check(g, func(a Account) bool {
+		for _, order := range a.Orders {
+			if order.Total >= 500 {
+				return false
+			}
+		}
+		return true
+	})
+
+	actual := counterexampleOf(t, checkErr).(Account)
+	if len(actual.Orders) != 1 || actual.Orders[0].Total != 500 {
+		t.Errorf("bound field was not shrunk using its generator, expected a single order of 500, got: %+v", actual.Orders)
+	}
+}
+
+func TestInferReturnsFieldPathErrors(t *testing.T) {
+	options := []InferOption{
+		Field("Missing", Only("x")),
+		Field("Home.Missing", Only("x")),
+		Field("Name.Length", Only(1)),
+		Field("Home.ZipCode", Only(1)),
+	}
+	for _, option := range options {
+		if _, err := Infer[Account](option); err == nil {
+			t.Errorf("Infer did not return an error for an invalid field path")
+		}
+	}
+}
+
+type Email string
+
+type Audit struct {
+	CreatedBy Email
+	internal  Email
+}
+
+type auditTrail struct {
+	Reviewers []Email
+}
+
+type Ticket struct {
+	Audit
+	auditTrail
+	Reporter Email
+	Watchers map[string]Email
+	Assignee *Email
+	Parent   *Audit
+	Subtasks []Audit
+}
+
+func TestInferUsesWrappedGeneratorsAtEveryDepth(t *testing.T) {
+	emailGen := Map(MustRegex(`[a-z]{3}@example\.com`), func(s string) Email { return Email(s) })
+	g, err := Infer[Ticket](Wrap(emailGen))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	isEmail := regexp.MustCompile(`^[a-z]{3}@example\.com$`).MatchString
+	for _, ticket := range GenerateN(g, 100) {
+		emails := append([]Email{ticket.CreatedBy, ticket.Reporter}, ticket.Reviewers...)
+		for _, email := range ticket.Watchers {
+			emails = append(emails, email)
+		}
+		if ticket.Assignee != nil {
+			emails = append(emails, *ticket.Assignee)
+		}
+		if ticket.Parent != nil {
+			emails = append(emails, ticket.Parent.CreatedBy)
+		}
+		for _, subtask := range ticket.Subtasks {
+			emails = append(emails, subtask.CreatedBy)
+		}
+		for _, email := range emails {
+			if !isEmail(string(email)) {
+				t.Fatalf("the wrapped generator was not used for a nested value: %q in %+v", email, ticket)
+			}
+		}
+		if ticket.internal != "" {
+			t.Fatalf("an unexported field was generated: %q", ticket.internal)
+		}
+	}
+}
+
+func TestInferShrinksNestedValuesUsingWrappedGenerators(t *testing.T) {
+	g, err := Infer[Account](Wrap(Between(100, 999)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkErr := Check(g, func(a Account) bool {
+		for _, order := range a.Orders {
+			if order.Total >= 500 {
+				return false
+			}
+		}
+		return true
+	})
+
+	actual := counterexampleOf(t, checkErr).(Account)
+	if len(actual.Orders) != 1 || actual.Orders[0].Total != 500 {
+		t.Errorf("nested value was not shrunk using the wrapped generator, expected a single order of 500, got: %+v", actual.Orders)
+	}
+}
+
+type Node struct {
+	Value    int
+	Children []*Node
+}
+
+type BinaryTree struct {
+	Left, Right *BinaryTree
+	Labels      map[string]BinaryTree
+}
+
+func (n *Node) depth() int {
+	if n == nil {
+		return 0
+	}
+	deepest := 0
+	for _, child := range n.Children {
+		if d := child.depth(); d > deepest {
+			deepest = d
+		}
+	}
+	return deepest + 1
+}
+
+func (t *BinaryTree) depth() int {
+	if t == nil {
+		return 0
+	}
+	deepest := numericMax(t.Left.depth(), t.Right.depth())
+	for _, label := range t.Labels {
+		deepest = numericMax(deepest, label.depth())
+	}
+	return deepest + 1
+}
+
+func TestInferNonStructTypes(t *testing.T) {
+	ints, err := Infer[[]int]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ints.Generate()) > defaultSize {
+		t.Errorf("the inferred slice is longer than the size")
+	}
+
+	addresses, err := Infer[map[string]Address](Field("ZipCode", Only("12345")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range GenerateN(addresses, 50) {
+		for _, address := range m {
+			if address.ZipCode != "12345" {
+				t.Fatalf("the field path was not used for the map values: %+v", address)
+			}
+		}
+	}
+
+	names, err := Infer[*string](Wrap(Only("name")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range GenerateN(names, 50) {
+		if name != nil && *name != "name" {
+			t.Fatalf("the wrapped generator was not used for the pointer element: %q", *name)
+		}
+	}
+}
+
+func TestInferRecursiveTypes(t *testing.T) {
+	nodes, err := Infer[*Node]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	trees, err := Infer[BinaryTree]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 100; i++ {
+		if d := nodes.Generate().depth(); d > defaultSize {
+			t.Fatalf("the depth of the inferred tree is not bounded by the size: %d", d)
+		}
+		tree := trees.Generate()
+		if d := tree.depth(); d > defaultSize+1 {
+			t.Fatalf("the depth of the inferred tree is not bounded by the size: %d", d)
+		}
+	}
+}
+
+func TestInferredNonStructTypesShrink(t *testing.T) {
+	g, err := Infer[[]int]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkErr := Check(g, func(values []int) bool { return len(values) < 3 })
+
+	if actual := counterexampleOf(t, checkErr).([]int); len(actual) != 3 || actual[0] != 0 {
+		t.Errorf("inferred slice was not shrunk, got: %v", actual)
+	}
+}
+
+type Strategy struct {
+	Name     string
+	Score    func(order Order) int
+	Pick     func(candidates ...string) (string, bool)
+	Callback func(string)
+	Next     func() *Customer
+}
+
+func TestInferGeneratesFunctions(t *testing.T) {
+	g, err := Infer[Strategy](Wrap(Between(1, 10)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, strategy := range GenerateN(g, 50) {
+		if strategy.Score == nil || strategy.Pick == nil || strategy.Callback == nil || strategy.Next == nil {
+			t.Fatalf("a function was not generated: %+v", strategy)
+		}
+		if score := strategy.Score(Order{Total: 1}); !isBetween(score, 1, 10) {
+			t.Fatalf("the wrapped generator was not used for the outputs of the function: %d", score)
+		}
+		strategy.Pick("a", "b")
+		strategy.Callback("event")
+		if customer := strategy.Next(); customer != nil && !isBetween(customer.Age, 18, 65) {
+			t.Fatalf("the tags were not honored within the outputs of the function: %+v", customer)
+		}
+	}
+
+	numbers, err := Infer[func(int) []int]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	GenerateWith(numbers, NewRand(1))(42)
+}
+
+func TestInferWithDeterministicFuncs(t *testing.T) {
+	g, err := Infer[func(string, Address) (int, string)](DeterministicFuncs())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range GenerateN(g, 20) {
+		n1, s1 := f("key", Address{Street: "street"})
+		n2, s2 := f("key", Address{Street: "street"})
+		if n1 != n2 || s1 != s2 {
+			t.Fatalf("the deterministic function returned different outputs for the same inputs: (%d, %q) and (%d, %q)", n1, s1, n2, s2)
+		}
+	}
+
+	distinct := make(map[int]bool)
+	f := g.Generate()
+	for i := 0; i < 20; i++ {
+		n, _ := f(strings.Repeat("k", i), Address{})
+		distinct[n] = true
+	}
+	if len(distinct) < 10 {
+		t.Errorf("the deterministic function returned the same outputs for different inputs: %v", distinct)
+	}
+}
+
+func TestInferredFunctionsReturnNewOutputsByDefault(t *testing.T) {
+	g, err := Infer[func() int64]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := g.Generate()
+	if f() == f() && f() == f() {
+		t.Errorf("the function returned the same outputs on each call")
+	}
+}
+
+func TestInferWithASliceOfWrappedGenerators(t *testing.T) {
+	gens := []*WrappedGen{Wrap(Only("name")), Wrap(Between(1, 10))}
+	g, err := Infer[TestPerson](Wrapped(gens...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, person := range GenerateN(g, 50) {
+		if person.Name != "name" || person.Surname != "name" || !isBetween(person.Age, 1, 10) {
+			t.Fatalf("the wrapped generators were not used: %+v", person)
+		}
+	}
+}
+
+func TestInferWithFieldPathsThroughEmbeddedStructs(t *testing.T) {
+	g, err := Infer[Ticket](
+		Field("auditTrail.Reviewers", Only([]Email{"reviewer"})),
+		Field("Audit.CreatedBy", Only(Email("creator"))),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, ticket := range GenerateN(g, 50) {
+		if len(ticket.Reviewers) != 1 || ticket.Reviewers[0] != "reviewer" || ticket.CreatedBy != "creator" {
+			t.Fatalf("the generators bound through the embedded structs were not used: %+v", ticket)
+		}
+	}
+
+	for _, path := range []string{"Reviewers", "auditTrail", "Audit.internal"} {
+		if _, err := Infer[Ticket](Field(path, Only([]Email{}))); err == nil {
+			t.Errorf("Infer did not return an error for the field path %s", path)
+		}
+	}
+}
+
+type Job struct {
+	Name    string
+	Err     error
+	Payload any `gen:"-"`
+	done    chan struct{}
+}
+
+func TestInferReturnsErrorsForUnsupportedTypes(t *testing.T) {
+	if _, err := Infer[chan int](); err == nil {
+		t.Errorf("Infer did not return an error for a channel")
+	}
+	if _, err := Infer[func() chan int](); err == nil {
+		t.Errorf("Infer did not return an error for a function returning a channel")
+	}
+	if _, err := Infer[[]map[string]*Job](); err == nil || !strings.Contains(err.Error(), "`error`") {
+		t.Errorf("Infer did not return the error of the unsupported field type, got: %v", err)
+	}
+
+	jobs, err := Infer[Job](Wrap(OneOf[error](nil, errors.New("failed"))))
+	if err != nil {
+		t.Fatalf("Infer returned an error although the interface has a wrapped generator: %v", err)
+	}
+	for _, job := range GenerateN(jobs, 20) {
+		if job.Err != nil && job.Err.Error() != "failed" {
+			t.Fatalf("the wrapped generator was not used for the interface: %v", job.Err)
+		}
+	}
+	if _, err := Infer[Job](Field("Err", Only[error](nil))); err != nil {
+		t.Errorf("Infer returned an error although the interface field has a bound generator: %v", err)
+	}
+}
@@ -0,0 +1,137 @@
+package gen
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Alphabets to be used with StringGen.
+const (
+	AlphabetLower        = "abcdefghijklmnopqrstuvwxyz"
+	AlphabetUpper        = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	AlphabetDigits       = "0123456789"
+	AlphabetLetters      = AlphabetLower + AlphabetUpper
+	AlphabetAlphanumeric = AlphabetLetters + AlphabetDigits
+	AlphabetHex          = "0123456789abcdef"
+	AlphabetBase64       = AlphabetUpper + AlphabetLower + AlphabetDigits + "+/"
+	AlphabetURLSafe      = AlphabetUpper + AlphabetLower + AlphabetDigits + "-_"
+	// AlphabetPrintable holds the printable ASCII characters, from the space to the tilde.
+	AlphabetPrintable = " !\"#$%&'()*+,-./" + AlphabetDigits + ":;<=>?@" + AlphabetUpper + "[\\]^_`" + AlphabetLower + "{|}~"
+)
+
+// Emoji is the range table of the most common emoji, which the unicode package does not have a table for.
+var Emoji = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x2600, Hi: 0x27bf, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1f300, Hi: 0x1f64f, Stride: 1},
+		{Lo: 0x1f680, Hi: 0x1f6ff, Stride: 1},
+		{Lo: 0x1f900, Hi: 0x1f9ff, Stride: 1},
+		{Lo: 0x1fa70, Hi: 0x1faff, Stride: 1},
+	},
+}
+
+// runeClassOf returns the class of the runes within the given range tables.
+func runeClassOf(tables ...*unicode.RangeTable) *runeClass {
+	c := &runeClass{}
+	add := func(lo, hi, stride rune) {
+		if stride == 1 {
+			c.add(lo, hi)
+			return
+		}
+		for r := lo; r <= hi; r += stride {
+			c.add(r, r)
+		}
+	}
+	for _, table := range tables {
+		for _, r16 := range table.R16 {
+			add(rune(r16.Lo), rune(r16.Hi), rune(r16.Stride))
+		}
+		for _, r32 := range table.R32 {
+			add(rune(r32.Lo), rune(r32.Hi), rune(r32.Stride))
+		}
+	}
+	return c
+}
+
+type unicodeRune struct {
+	class *runeClass
+}
+
+func (u unicodeRune) Generate() rune { return u.generate(defaultRand) }
+
+func (u unicodeRune) generate(r *Rand) rune { return u.class.pick(r) }
+
+// UnicodeRuneGen generates runes within the given unicode range tables, which are either categories
+// (e.g., unicode.Letter), scripts (e.g., unicode.Han) or properties of the unicode package, or the Emoji table.
+// Runes which are within several tables are more likely to be generated.
+func UnicodeRuneGen(tables ...*unicode.RangeTable) Gen[rune] {
+	class := runeClassOf(tables...)
+	if class.total == 0 {
+		panic("gen: UnicodeRuneGen requires at least one rune")
+	}
+	return unicodeRune{class}
+}
+
+// UnicodeStringGen is like StringGen, but generates strings of the runes within the given unicode range tables,
+// see UnicodeRuneGen:
+//
+//	chineseNameGen := gen.UnicodeStringGen(2, 4, unicode.Han)
+func UnicodeStringGen(minLength, maxLength uint, tables ...*unicode.RangeTable) Gen[string] {
+	class := runeClassOf(tables...)
+	if class.total == 0 {
+		panic("gen: UnicodeStringGen requires at least one rune")
+	}
+	return &stringGen{class, int(numericMin(minLength, maxLength)), int(numericMax(minLength, maxLength))}
+}
+
+var (
+	// trickyClasses are the classes of the runes which tricky strings are made of, the first of which
+	// (the printable ASCII characters) is the simplest.
+	trickyClasses = []*runeClass{
+		runeClassOf(&unicode.RangeTable{R16: []unicode.Range16{{Lo: 0x20, Hi: 0x7e, Stride: 1}}}),
+		runeClassOf(unicode.Mn),
+		runeClassOf(unicode.Hebrew, unicode.Arabic),
+		// The zero-width characters, and the bidirectional text controls.
+		newRuneClass([]rune{0x200b, 0x200f, 0x202a, 0x202e, 0x2060, 0x2060, 0x2066, 0x2069, 0xfeff, 0xfeff}),
+		runeClassOf(Emoji),
+	}
+
+	// invalidUTF8 holds byte sequences which are not valid UTF-8: invalid bytes, a lone continuation byte,
+	// an overlong encoding, a truncated sequence, an encoded surrogate, and an encoded rune beyond the unicode range.
+	invalidUTF8 = []string{"\xff", "\xfe", "\x80", "\xc0\x80", "\xe2\x82", "\xed\xa0\x80", "\xf4\x90\x80\x80"}
+)
+
+type trickyString struct {
+	minLength, maxLength int
+	invalidUTF8          bool
+}
+
+func (t trickyString) Generate() string { return t.generate(defaultRand) }
+
+func (t trickyString) generate(r *Rand) string {
+	var b strings.Builder
+	n := collectionLength(r, t.minLength, t.maxLength)
+	kinds := len(trickyClasses)
+	if t.invalidUTF8 {
+		kinds++
+	}
+	for i := 0; i < n; i++ {
+		kind := r.intn(kinds)
+		if kind == len(trickyClasses) {
+			b.WriteString(invalidUTF8[r.intn(len(invalidUTF8))])
+		} else {
+			b.WriteRune(trickyClasses[kind].pick(r))
+		}
+	}
+	return b.String()
+}
+
+// TrickyStringGen generates strings which deliberately include the characters that commonly break text handling:
+// combining marks, right-to-left text, bidirectional text controls, zero-width characters and emoji,
+// mixed with printable ASCII characters. If invalidUTF8 is set, the strings also include invalid UTF-8 byte sequences.
+// The length is the number of these characters (or byte sequences), which are made of one or more bytes each.
+func TrickyStringGen(minLength, maxLength uint, invalidUTF8 bool) Gen[string] {
+	return trickyString{int(numericMin(minLength, maxLength)), int(numericMax(minLength, maxLength)), invalidUTF8}
+}
@@ -0,0 +1,72 @@
+package gen
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+)
+
+func TestAlphabets(t *testing.T) {
+	if len(AlphabetPrintable) != 95 || len(AlphabetBase64) != 64 || len(AlphabetURLSafe) != 64 {
+		t.Fatalf("unexpected alphabet lengths, printable: %d, base64: %d, url-safe: %d",
+			len(AlphabetPrintable), len(AlphabetBase64), len(AlphabetURLSafe))
+	}
+
+	for _, value := range GenerateN(StringGen(AlphabetURLSafe, 4, 4), 100) {
+		if _, err := base64.RawURLEncoding.DecodeString(value); err != nil {
+			t.Fatalf("a string of the url-safe alphabet is not url-safe base64: %s", value)
+		}
+	}
+}
+
+func TestUnicodeStringGen(t *testing.T) {
+	g := UnicodeStringGen(1, 10, unicode.Han)
+
+	for _, value := range GenerateN(g, 100) {
+		for _, r := range value {
+			if !unicode.Is(unicode.Han, r) {
+				t.Fatalf("UnicodeStringGen generated a rune out of its tables: %q", r)
+			}
+		}
+	}
+
+	for _, r := range GenerateN(UnicodeRuneGen(unicode.Letter, Emoji), 1000) {
+		if !unicode.IsLetter(r) && !unicode.Is(Emoji, r) {
+			t.Fatalf("UnicodeRuneGen generated a rune out of its tables: %q", r)
+		}
+	}
+}
+
+func TestTrickyStringGen(t *testing.T) {
+	var sawCombining, sawRTL, sawZeroWidth bool
+	for _, value := range GenerateN(TrickyStringGen(0, 20, false), 200) {
+		if !utf8.ValidString(value) {
+			t.Fatalf("TrickyStringGen generated invalid UTF-8 although it was not asked to: %q", value)
+		}
+		sawCombining = sawCombining || strings.IndexFunc(value, func(r rune) bool { return unicode.Is(unicode.Mn, r) }) >= 0
+		sawRTL = sawRTL || strings.IndexFunc(value, func(r rune) bool { return unicode.Is(unicode.Hebrew, r) || unicode.Is(unicode.Arabic, r) }) >= 0
+		sawZeroWidth = sawZeroWidth || strings.ContainsAny(value, "\u200b\u200c\u200d\u2060\ufeff")
+	}
+	if !sawCombining || !sawRTL || !sawZeroWidth {
+		t.Errorf("TrickyStringGen did not generate the tricky characters, combining marks: %v, RTL: %v, zero-width: %v",
+			sawCombining, sawRTL, sawZeroWidth)
+	}
+
+	sawInvalid := false
+	for _, value := range GenerateN(TrickyStringGen(1, 20, true), 200) {
+		sawInvalid = sawInvalid || !utf8.ValidString(value)
+	}
+	if !sawInvalid {
+		t.Errorf("TrickyStringGen did not generate invalid UTF-8")
+	}
+}
+
+func TestTrickyStringGenShrinksToASCII(t *testing.T) {
+	err := Check(TrickyStringGen(0, 20, false), func(s string) bool { return utf8.RuneCountInString(s) < 3 })
+
+	if actual := counterexampleOf(t, err); actual != "   " {
+		t.Errorf("TrickyStringGen was not shrunk to the smallest counterexample, expected: 3 spaces, got: %q", actual)
+	}
+}
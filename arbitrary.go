@@ -2,15 +2,32 @@ package gen
 
 import "math"
 
+// Arbitrary generates values within the whole domain of T, mixed with its edge cases (see EdgeCases).
+// The edge cases of floats include NaN, +Inf and -Inf as well. By default, 1 out of 10 values is an edge case,
+// which can be changed using the EdgeCases option:
+//
+//	indexGen := gen.Arbitrary[int](gen.EdgeCases(0.5))
+func Arbitrary[T Numeric](options ...NumericOption) Gen[T] {
+	min, max := numericBounds[T]()
+	options = append([]NumericOption{EdgeCases(defaultEdgeCaseRatio)}, options...)
+	return newBetween(min, max, true, options)
+}
+
 // ------ int types ------
-// ArbitraryInt is an arbitrary int generator within int min value / 2 and int max value / 2
-var ArbitraryInt Gen[int] = Between((math.MinInt/2 + 1), (math.MaxInt/2 - 1))
+// ArbitraryInt is an arbitrary int generator within int min value and int max value
+var ArbitraryInt Gen[int] = Between(math.MinInt, math.MaxInt)
+
+// ArbitraryInt8 is an arbitrary int8 generator within int8 min value and int8 max value
+var ArbitraryInt8 Gen[int8] = Between(int8(math.MinInt8), int8(math.MaxInt8))
+
+// ArbitraryInt16 is an arbitrary int16 generator within int16 min value and int16 max value
+var ArbitraryInt16 Gen[int16] = Between(int16(math.MinInt16), int16(math.MaxInt16))
 
-// ArbitraryInt32 is an arbitrary int32 generator within int32 min value / 2 and int32 max value / 2
-var ArbitraryInt32 Gen[int32] = Between(int32(math.MinInt32)/2+1, int32(math.MaxInt32)/2-1)
+// ArbitraryInt32 is an arbitrary int32 generator within int32 min value and int32 max value
+var ArbitraryInt32 Gen[int32] = Between(int32(math.MinInt32), int32(math.MaxInt32))
 
-// ArbitraryInt64 is an arbitrary int64 generator within int64 min value / 2 and int64 max value / 2
-var ArbitraryInt64 Gen[int64] = Between(int64(math.MinInt64)/2+1, int64(math.MaxInt64)/2-1)
+// ArbitraryInt64 is an arbitrary int64 generator within int64 min value and int64 max value
+var ArbitraryInt64 Gen[int64] = Between(int64(math.MinInt64), int64(math.MaxInt64))
 
 // ------ uint types ------
 // ArbitraryUint is an arbitrary uint generator within 0 and uint max value
@@ -29,11 +46,30 @@ var ArbitraryUint32 Gen[uint32] = Between(uint32(0), uint32(math.MaxUint32))
 var ArbitraryUint64 Gen[uint64] = Between(uint64(0), uint64(math.MaxUint64))
 
 // ------ float types ------
-// ArbitraryFloat32 is an arbitrary float32 generator within float32 min value / 2 and float32 max value / 2
-var ArbitraryFloat32 Gen[float32] = Between(float32(math.MinInt32)/2+1, float32(math.MaxInt32)/2-1)
+// ArbitraryFloat32 is an arbitrary float32 generator, which generates all the finite float32 values,
+// from the subnormal ones to the float32 max value, with every magnitude being as likely.
+var ArbitraryFloat32 Gen[float32] = newBetween(float32(-math.MaxFloat32), float32(math.MaxFloat32), true, nil)
 
-// ArbitraryFloat64 is an arbitrary float64 generator within float64 min value / 2 and float32 max value / 2
-var ArbitraryFloat64 Gen[float64] = Between(float64(math.MinInt64)/2+1, float64(math.MaxInt64)/2-1)
+// ArbitraryFloat64 is an arbitrary float64 generator, which generates all the finite float64 values,
+// from the subnormal ones to the float64 max value, with every magnitude being as likely.
+var ArbitraryFloat64 Gen[float64] = newBetween(-math.MaxFloat64, math.MaxFloat64, true, nil)
+
+// ------ complex types ------
+// ArbitraryComplex64 is an arbitrary complex64 generator, whose real and imaginary parts are arbitrary float32s
+var ArbitraryComplex64 Gen[complex64] = Complex64Gen(ArbitraryFloat32, ArbitraryFloat32)
+
+// ArbitraryComplex128 is an arbitrary complex128 generator, whose real and imaginary parts are arbitrary float64s
+var ArbitraryComplex128 Gen[complex128] = Complex128Gen(ArbitraryFloat64, ArbitraryFloat64)
+
+// Complex64Gen generates complex64 values, using the given generators for their real and imaginary parts.
+func Complex64Gen(realGen, imagGen Gen[float32]) Gen[complex64] {
+	return Map2(realGen, imagGen, func(r, i float32) complex64 { return complex(r, i) })
+}
+
+// Complex128Gen generates complex128 values, using the given generators for their real and imaginary parts.
+func Complex128Gen(realGen, imagGen Gen[float64]) Gen[complex128] {
+	return Map2(realGen, imagGen, func(r, i float64) complex128 { return complex(r, i) })
+}
 
 // ------ rune ------
 // ArbitraryRune is an arbitrary rune generator.
@@ -42,20 +78,42 @@ var ArbitraryRune Gen[rune] = ArbitraryInt32
 // ------ string ------
 
 type stringGen struct {
-	alphabet             []rune
+	alphabet             *runeClass
 	minLength, maxLength int
 }
 
-func (s *stringGen) Generate() string {
-	strlen := Between(s.minLength, s.maxLength).Generate()
-	rs := GenerateN(OneOf(s.alphabet...), uint(strlen))
+func (s *stringGen) Generate() string { return s.generate(defaultRand) }
+
+func (s *stringGen) generate(r *Rand) string {
+	return s.runes(r, GenerateWith(Between(s.minLength, r.scale(s.minLength, s.maxLength)), r))
+}
+
+// sized generates a string whose length exceeds the minimum length by at most the given size.
+func (s *stringGen) sized(r *Rand, size int) string {
+	max := s.maxLength
+	if size >= 0 && s.minLength+size < max {
+		max = s.minLength + size
+	}
+	return s.runes(r, s.minLength+r.intn(max-s.minLength+1))
+}
+
+func (s *stringGen) runes(r *Rand, length int) string {
+	rs := make([]rune, length)
+	for i := range rs {
+		rs[i] = s.alphabet.pick(r)
+	}
 	return string(rs)
 }
 
 // StringGen is a string generator that generates random strings using the given alphabet and minLength and maxLength.
+// The length of the strings respects the size of the Rand they're generated from, see Sized.
 func StringGen(alphabet string, minLength uint, maxLength uint) Gen[string] {
 	actualMin := numericMin(minLength, maxLength)
 	actualMax := numericMax(minLength, maxLength)
 
-	return &stringGen{[]rune(alphabet), int(actualMin), int(actualMax)}
+	class := &runeClass{}
+	for _, r := range alphabet {
+		class.add(r, r)
+	}
+	return &stringGen{class, int(actualMin), int(actualMax)}
 }
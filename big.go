@@ -0,0 +1,90 @@
+package gen
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// randBits draws a non-negative integer of up to the given number of bits, the number of which grows with the size.
+func randBits(r *Rand, maxBits uint) *big.Int {
+	bits := uint(r.draw(uint64(r.scale(0, int(maxBits)))))
+	n := new(big.Int)
+	chunk := new(big.Int)
+	for bits > 0 {
+		chunkBits := uint(64)
+		if bits < chunkBits {
+			chunkBits = bits
+		}
+		n.Lsh(n, chunkBits)
+		n.Or(n, chunk.SetUint64(r.draw(math.MaxUint64>>(64-chunkBits))))
+		bits -= chunkBits
+	}
+	return n
+}
+
+// randSignedBits draws an integer whose absolute value has up to the given number of bits.
+func randSignedBits(r *Rand, maxBits uint) *big.Int {
+	negative := r.draw(1) == 1
+	n := randBits(r, maxBits)
+	if negative {
+		n.Neg(n)
+	}
+	return n
+}
+
+type bigInt struct {
+	maxBits uint
+}
+
+func (b bigInt) Generate() *big.Int { return b.generate(defaultRand) }
+
+func (b bigInt) generate(r *Rand) *big.Int { return randSignedBits(r, b.maxBits) }
+
+// BigInt generates integers within (-2**maxBits, 2**maxBits), the bit length of which grows with the size.
+func BigInt(maxBits uint) Gen[*big.Int] {
+	return bigInt{maxBits}
+}
+
+type bigRat struct {
+	maxBits uint
+}
+
+func (b bigRat) Generate() *big.Rat { return b.generate(defaultRand) }
+
+func (b bigRat) generate(r *Rand) *big.Rat {
+	num := randSignedBits(r, b.maxBits)
+	denom := randBits(r, b.maxBits)
+	return new(big.Rat).SetFrac(num, denom.Add(denom, big.NewInt(1)))
+}
+
+// BigRat generates rational numbers, whose numerator and denominator have up to maxBits bits.
+func BigRat(maxBits uint) Gen[*big.Rat] {
+	return bigRat{maxBits}
+}
+
+type bigFloat struct {
+	prec   uint
+	maxExp int
+}
+
+func (b bigFloat) Generate() *big.Float { return b.generate(defaultRand) }
+
+func (b bigFloat) generate(r *Rand) *big.Float {
+	mant := randSignedBits(r, b.prec)
+	exp := uniformNumeric(r, -b.maxExp, b.maxExp)
+	f := new(big.Float).SetPrec(b.prec).SetInt(mant)
+	return f.SetMantExp(f, exp)
+}
+
+// BigFloat generates floats of the given precision (in bits), whose (binary) exponent is within [-maxExp, maxExp],
+// i.e., values of the form mant * 2**exp, where mant has up to prec bits.
+func BigFloat(prec uint, maxExp int) Gen[*big.Float] {
+	if prec == 0 || prec > big.MaxPrec {
+		panic(fmt.Errorf("gen: BigFloat precision must be within [1, %d], got: %d", uint(big.MaxPrec), prec))
+	}
+	if maxExp < 0 {
+		maxExp = -maxExp
+	}
+	return bigFloat{prec, maxExp}
+}
@@ -0,0 +1,59 @@
+package gen
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestBigInt(t *testing.T) {
+	limit := new(big.Int).Lsh(big.NewInt(1), 200)
+	var sawNegative, sawLarge bool
+	for _, value := range GenerateN(BigInt(200), 200) {
+		if new(big.Int).Abs(value).Cmp(limit) >= 0 {
+			t.Fatalf("BigInt generated a value of more than 200 bits: %v", value)
+		}
+		sawNegative = sawNegative || value.Sign() < 0
+		sawLarge = sawLarge || value.BitLen() > 64
+	}
+	if !sawNegative || !sawLarge {
+		t.Errorf("BigInt did not generate negative values or values larger than 64 bits")
+	}
+}
+
+func TestBigIntRespectsTheSize(t *testing.T) {
+	r := NewRand(1)
+	small := GenerateWith(Resize(BigInt(1000), 1), r)
+	if small.BitLen() > 20 {
+		t.Errorf("BigInt generated a %d bits value with a size of 1", small.BitLen())
+	}
+}
+
+func TestBigRat(t *testing.T) {
+	for _, value := range GenerateN(BigRat(100), 100) {
+		if value.Num().BitLen() > 100 || value.Denom().Sign() <= 0 {
+			t.Fatalf("BigRat generated an unexpected value: %v", value)
+		}
+	}
+}
+
+func TestBigFloat(t *testing.T) {
+	max := new(big.Float).SetMantExp(big.NewFloat(1), 64+10)
+	for _, value := range GenerateN(BigFloat(64, 10), 100) {
+		if value.Prec() != 64 {
+			t.Fatalf("BigFloat generated a value with the precision of %d", value.Prec())
+		}
+		if new(big.Float).Abs(value).Cmp(max) > 0 {
+			t.Fatalf("BigFloat generated a value out of its range: %v", value)
+		}
+	}
+}
+
+func TestBigIntShrinking(t *testing.T) {
+	threshold := big.NewInt(1000)
+	err := Check(BigInt(128), func(n *big.Int) bool { return n.Cmp(threshold) < 0 })
+
+	actual := counterexampleOf(t, err).(*big.Int)
+	if actual.Cmp(big.NewInt(1024)) > 0 || actual.Cmp(threshold) < 0 {
+		t.Errorf("BigInt was not shrunk to a small counterexample, got: %v", actual)
+	}
+}
@@ -0,0 +1,162 @@
+package gen
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// collectionLength draws the length of a collection within [minLen, maxLen], respecting the size of the Rand.
+func collectionLength(r *Rand, minLen, maxLen int) int {
+	return minLen + r.intn(r.scale(minLen, maxLen)-minLen+1)
+}
+
+type sliceOf[T any] struct {
+	elem           Gen[T]
+	minLen, maxLen int
+}
+
+func (s *sliceOf[T]) Generate() []T { return s.generate(defaultRand) }
+
+func (s *sliceOf[T]) generate(r *Rand) []T {
+	values := make([]T, collectionLength(r, s.minLen, s.maxLen))
+	for i := range values {
+		values[i] = GenerateWith(s.elem, r)
+	}
+	return values
+}
+
+// Shrink removes elements from the slice without going below its minimum length,
+// and then shrinks the elements if the element generator is a Shrinker.
+func (s *sliceOf[T]) Shrink(value []T) [][]T {
+	var shrinkElem func(T) []T
+	if elemShrinker, ok := shrinkerOf(s.elem); ok {
+		shrinkElem = elemShrinker.Shrink
+	}
+	return shrinkSlice(value, s.minLen, shrinkElem)
+}
+
+// SliceOf generates slices of the values generated by the given generator, with lengths within [minLen, maxLen] (inclusive).
+// The order of the lengths doesn't matter, and the length of the slices respects the size of the Rand they're generated from.
+func SliceOf[T any](g Gen[T], minLen, maxLen uint) Gen[[]T] {
+	return &sliceOf[T]{g, int(numericMin(minLen, maxLen)), int(numericMax(minLen, maxLen))}
+}
+
+// NonEmptySliceOf generates slices of the values generated by the given generator, with lengths within [1, maxLen].
+func NonEmptySliceOf[T any](g Gen[T], maxLen uint) Gen[[]T] {
+	return SliceOf(g, 1, numericMax(1, maxLen))
+}
+
+type arrayOf[A any, T any] struct {
+	elem Gen[T]
+}
+
+func (a *arrayOf[A, T]) Generate() A { return a.generate(defaultRand) }
+
+func (a *arrayOf[A, T]) generate(r *Rand) A {
+	var array A
+	v := reflect.ValueOf(&array).Elem()
+	for i := 0; i < v.Len(); i++ {
+		elem := GenerateWith(a.elem, r)
+		v.Index(i).Set(reflect.ValueOf(&elem).Elem())
+	}
+	return array
+}
+
+// ArrayOf generates arrays of type `A` (which must be an array of `T`s), filled with the values generated by the given generator.
+// Since the length of an array is a part of its type, it has to be given explicitly:
+//
+//	ipGen := gen.ArrayOf[[4]byte](gen.ArbitraryUint8)
+func ArrayOf[A any, T any](g Gen[T]) Gen[A] {
+	arrayType, elemType := reflect.TypeOf(*new(A)), reflect.TypeOf(new(T)).Elem()
+	if arrayType == nil || arrayType.Kind() != reflect.Array || arrayType.Elem() != elemType {
+		panic(fmt.Errorf("gen: ArrayOf needs an array of %s, got: %v", elemType, arrayType))
+	}
+	return &arrayOf[A, T]{g}
+}
+
+// distinct draws up to n distinct values from the given generator, allowing up to defaultMaxDiscards collisions
+// with the values drawn before, and calls add for each of them. It panics with a *FilterError if less than minLen
+// distinct values could be drawn, so the property runner discards the iteration.
+func distinct[K comparable](r *Rand, g Gen[K], n, minLen int, add func(K)) {
+	seen := make(map[K]struct{}, n)
+	for collisions := 0; len(seen) < n; {
+		key := GenerateWith(g, r)
+		if _, exists := seen[key]; exists {
+			if collisions++; collisions > defaultMaxDiscards {
+				if len(seen) < minLen {
+					panic(&FilterError{defaultMaxDiscards})
+				}
+				return
+			}
+			continue
+		}
+		seen[key] = struct{}{}
+		add(key)
+	}
+}
+
+type mapOf[K comparable, V any] struct {
+	keys           Gen[K]
+	values         Gen[V]
+	minLen, maxLen int
+}
+
+func (m *mapOf[K, V]) Generate() map[K]V { return m.generate(defaultRand) }
+
+func (m *mapOf[K, V]) generate(r *Rand) map[K]V {
+	n := collectionLength(r, m.minLen, m.maxLen)
+	result := make(map[K]V, n)
+	distinct(r, m.keys, n, m.minLen, func(key K) { result[key] = GenerateWith(m.values, r) })
+	return result
+}
+
+// Shrink removes keys from the map without going below its minimum length,
+// and then shrinks the values if the value generator is a Shrinker.
+func (m *mapOf[K, V]) Shrink(value map[K]V) []map[K]V {
+	valueShrinker, _ := shrinkerOf(m.values)
+	return atLeast(MapShrinker[K](valueShrinker).Shrink(value), m.minLen)
+}
+
+// MapOf generates maps using the given key and value generators, with lengths within [minLen, maxLen] (inclusive).
+// When a generated key collides with the keys generated before, another key is generated instead,
+// and if the key generator cannot generate enough distinct keys, the map is shorter than intended.
+// If even minLen distinct keys cannot be generated, it panics with a *FilterError, just like Filter does.
+func MapOf[K comparable, V any](keys Gen[K], values Gen[V], minLen, maxLen uint) Gen[map[K]V] {
+	return &mapOf[K, V]{keys, values, int(numericMin(minLen, maxLen)), int(numericMax(minLen, maxLen))}
+}
+
+type setOf[T comparable] struct {
+	elem           Gen[T]
+	minLen, maxLen int
+}
+
+func (s *setOf[T]) Generate() map[T]struct{} { return s.generate(defaultRand) }
+
+func (s *setOf[T]) generate(r *Rand) map[T]struct{} {
+	n := collectionLength(r, s.minLen, s.maxLen)
+	result := make(map[T]struct{}, n)
+	distinct(r, s.elem, n, s.minLen, func(elem T) { result[elem] = struct{}{} })
+	return result
+}
+
+// Shrink removes elements from the set without going below its minimum length.
+func (s *setOf[T]) Shrink(value map[T]struct{}) []map[T]struct{} {
+	return atLeast(MapShrinker[T, struct{}](nil).Shrink(value), s.minLen)
+}
+
+// SetOf generates sets of unique elements generated by the given generator, with lengths within [minLen, maxLen] (inclusive).
+// Collisions are handled just like they are in MapOf.
+func SetOf[T comparable](g Gen[T], minLen, maxLen uint) Gen[map[T]struct{}] {
+	return &setOf[T]{g, int(numericMin(minLen, maxLen)), int(numericMax(minLen, maxLen))}
+}
+
+// atLeast filters out the maps with less than minLen keys.
+func atLeast[K comparable, V any](maps []map[K]V, minLen int) []map[K]V {
+	result := maps[:0]
+	for _, m := range maps {
+		if len(m) >= minLen {
+			result = append(result, m)
+		}
+	}
+	return result
+}
@@ -0,0 +1,111 @@
+package gen
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSliceOf(t *testing.T) {
+	g := SliceOf(Between(0, 10), 5, 2)
+
+	lengths := make(map[int]bool)
+	for _, slice := range GenerateN(g, 200) {
+		if !isBetween(len(slice), 2, 5) {
+			t.Fatalf("SliceOf generated a slice with an unexpected length: %v", slice)
+		}
+		for _, value := range slice {
+			if !isBetween(value, 0, 10) {
+				t.Fatalf("SliceOf generated an unexpected element: %d", value)
+			}
+		}
+		lengths[len(slice)] = true
+	}
+	if len(lengths) != 4 {
+		t.Errorf("SliceOf did not generate all the lengths within the range, got: %v", lengths)
+	}
+}
+
+func TestNonEmptySliceOf(t *testing.T) {
+	for _, slice := range GenerateN(Resize(NonEmptySliceOf(ArbitraryInt, 10), 0), 100) {
+		if len(slice) != 1 {
+			t.Fatalf("NonEmptySliceOf did not generate a single element at size 0: %v", slice)
+		}
+	}
+}
+
+func TestSliceOfShrinking(t *testing.T) {
+	g := SliceOf(Between(0, 100), 2, 40)
+
+	err := Check(g, func(s []int) bool {
+		for _, value := range s {
+			if value > 50 {
+				return false
+			}
+		}
+		return true
+	})
+
+	if actual := counterexampleOf(t, err); !reflect.DeepEqual(actual, []int{0, 51}) && !reflect.DeepEqual(actual, []int{51, 0}) {
+		t.Errorf("SliceOf was not shrunk to the smallest counterexample, expected: [0 51], got: %v", actual)
+	}
+}
+
+func TestArrayOf(t *testing.T) {
+	ip := GenerateWith(ArrayOf[[4]byte](Between(byte(1), byte(10))), NewRand(1))
+
+	for _, b := range ip {
+		if b < 1 || b > 10 {
+			t.Fatalf("ArrayOf generated an unexpected element: %v", ip)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("ArrayOf did not panic for a type which is not an array of the elements")
+		}
+	}()
+	ArrayOf[[]byte](ArbitraryUint8)
+}
+
+func TestMapOfHandlesKeyCollisions(t *testing.T) {
+	g := MapOf(Between(0, 5), StringGen("ab", 1, 3), 3, 10)
+
+	for _, m := range GenerateN(g, 100) {
+		if len(m) < 3 || len(m) > 6 {
+			t.Fatalf("MapOf generated a map with an unexpected length: %v", m)
+		}
+	}
+}
+
+func TestMapOfDiscardsWhenKeysAreExhausted(t *testing.T) {
+	g := MapOf(OneOf(1, 2), Only("x"), 3, 3)
+
+	err := Check(g, func(map[int]string) bool { return true }, Iterations(5))
+	if _, ok := err.(*GaveUpError); !ok {
+		t.Errorf("MapOf did not give up when it could not generate enough distinct keys, got: %v", err)
+	}
+}
+
+func TestSetOf(t *testing.T) {
+	g := SetOf(StringGen("abc", 2, 2), 9, 9)
+
+	for _, set := range GenerateN(g, 20) {
+		if len(set) != 9 {
+			t.Fatalf("SetOf did not generate all the unique elements: %v", set)
+		}
+	}
+}
+
+func TestCollectionsCompose(t *testing.T) {
+	g := FlatMap(Between(1, 5), func(n int) Gen[[]string] {
+		return SliceOf(StringGen("xyz", uint(n), uint(n)), 1, 3)
+	})
+
+	for _, slice := range GenerateN(g, 100) {
+		for _, s := range slice {
+			if len(s) != len(slice[0]) {
+				t.Fatalf("composed collections did not share the flat-mapped length: %v", slice)
+			}
+		}
+	}
+}
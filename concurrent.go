@@ -0,0 +1,30 @@
+package gen
+
+import "sync"
+
+type concurrent[T any] struct {
+	underlying Gen[T]
+	lock       sync.Mutex
+}
+
+func (c *concurrent[T]) Generate() T {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.underlying.Generate()
+}
+
+func (c *concurrent[T]) generate(r *Rand) T {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return GenerateWith(c.underlying, r)
+}
+
+// Concurrent makes the given generator safe for concurrent use, by making sure that only one goroutine uses it at a time.
+//
+// All of gen's generators are already safe for concurrent use: the stateless ones draw from the package-level random
+// generator which is safe for concurrent use, and the stateful ones (like Sequential) synchronize their state.
+// Concurrent is meant for custom generators which hold a state, or use a random generator that is not safe for concurrent use.
+// Note that a Rand is not safe for concurrent use, so each goroutine using GenerateWith must own its Rand.
+func Concurrent[T any](g Gen[T]) Gen[T] {
+	return &concurrent[T]{underlying: g}
+}
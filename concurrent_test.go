@@ -0,0 +1,106 @@
+package gen
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	goroutines      = 8
+	valuesPerWorker = 500
+)
+
+// hammer generates values using the given generator from multiple goroutines at the same time,
+// it's meant to be run with the -race flag.
+func hammer[T any](t *testing.T, name string, g Gen[T]) {
+	t.Run(name, func(t *testing.T) {
+		t.Parallel()
+		var wg sync.WaitGroup
+		for i := 0; i < goroutines; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = GenerateN(g, valuesPerWorker)
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+func TestGeneratorsAreSafeForConcurrentUse(t *testing.T) {
+	now := time.Now()
+	inferred, err := Infer[TestPerson]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hammer(t, "only", Only(42))
+	hammer(t, "one-of", OneOf("a", "b", "c"))
+	hammer(t, "between", Between(-100, 100))
+	hammer(t, "between-float", Between(-1.5, 1.5))
+	hammer(t, "arbitrary-int", ArbitraryInt)
+	hammer(t, "arbitrary-uint64", ArbitraryUint64)
+	hammer(t, "arbitrary-float64", ArbitraryFloat64)
+	hammer(t, "string", StringGen("abcdef", 0, 20))
+	hammer(t, "time-between", TimeBetween(now, now.Add(time.Hour)))
+	hammer(t, "sequential", Sequential(0, 100, 3))
+	hammer(t, "time-seq", TimeSeq(now, now.Add(time.Hour), time.Minute))
+	hammer(t, "map", Map(ArbitraryInt, func(n int) int { return n / 2 }))
+	hammer(t, "flat-map", FlatMap(Between(0, 10), func(n int) Gen[string] { return StringGen("xyz", 0, uint(n)) }))
+	hammer(t, "map-n", Map3(ArbitraryInt, OneOf("a", "b"), Between(0, 10), func(a int, b string, c int) TestPerson {
+		return TestPerson{b, b, a + c}
+	}))
+	hammer(t, "infer", inferred)
+	hammer(t, "with-shrinker", WithShrinker(ArbitraryInt, NumericShrinker[int]()))
+	hammer(t, "pure-with", PureWith(func(r *Rand) int { return r.Intn(100) }))
+	hammer(t, "resize", Resize(ArbitraryInt, 5))
+	hammer(t, "sized", Sized(func(size int) Gen[[]int] { return SliceOf(Resize(ArbitraryInt, size), 0, uint(size)) }))
+
+	counter := 0
+	hammer(t, "concurrent", Concurrent(Pure(func() int {
+		counter++
+		return counter
+	})))
+
+	t.Run("seed", func(t *testing.T) {
+		t.Parallel()
+		for i := 0; i < valuesPerWorker; i++ {
+			Seed(int64(i))
+		}
+	})
+}
+
+func TestSequentialGeneratesEachValueOnceAcrossGoroutines(t *testing.T) {
+	total := goroutines * valuesPerWorker
+	s := Sequential(0, total*2, 1)
+
+	results := make(chan []int, goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() { results <- GenerateN(s, valuesPerWorker) }()
+	}
+
+	seen := make(map[int]struct{}, total)
+	for i := 0; i < goroutines; i++ {
+		for _, value := range <-results {
+			if _, exists := seen[value]; exists {
+				t.Fatalf("sequential generator generated %d more than once", value)
+			}
+			seen[value] = struct{}{}
+		}
+	}
+	for value := 0; value < total; value++ {
+		if _, exists := seen[value]; !exists {
+			t.Fatalf("sequential generator skipped %d", value)
+		}
+	}
+}
+
+func TestParallelForAll(t *testing.T) {
+	for i := 0; i < goroutines; i++ {
+		t.Run("parallel", func(t *testing.T) {
+			t.Parallel()
+			ForAll(t, Between(0, 100), func(n int) bool { return isBetween(n, 0, 100) })
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package gen
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+var defaultCorpusDir = filepath.Join("testdata", "gen")
+
+type corpusEntry[T any] struct {
+	file  string
+	value T
+}
+
+// testCorpusDir returns the directory of the corpus of the values of type `T` checked by the given test,
+// or an empty string if the corpus is disabled. The corpus is keyed by the type as well as the test,
+// so that the properties of different types which are checked by the same test do not share their entries.
+func testCorpusDir[T any](config *Config, testName string) string {
+	if config.CorpusDir == "" || testName == "" {
+		return ""
+	}
+	return filepath.Join(config.CorpusDir, filepath.FromSlash(testName), corpusTypeDir(reflect.TypeOf((*T)(nil)).Elem()))
+}
+
+// corpusTypeDir returns the name of the directory of the corpus entries of the given type, which is the name of the type,
+// with the characters that are not safe in file names (like the brackets of "[]int") replaced with underscores.
+func corpusTypeDir(t reflect.Type) string {
+	return strings.Map(func(c rune) rune {
+		if c == '.' || c == '-' || c < unicode.MaxASCII && (unicode.IsLetter(c) || unicode.IsDigit(c)) {
+			return c
+		}
+		return '_'
+	}, t.String())
+}
+
+// loadCorpus reads the entries of the corpus in the given directory, in the order of their file names.
+// Entries which cannot be decoded into `T` (e.g., because `T` has changed since they were saved) are reported
+// as skipped instead of failing the whole corpus, and so are the ones containing fields that `T` doesn't have.
+func loadCorpus[T any](dir string) (entries []corpusEntry[T], skipped map[string]error, err error) {
+	files, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, nil
+	} else if err != nil {
+		return nil, nil, err
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Name() < files[j].Name() })
+
+	skipped = make(map[string]error)
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, file.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		var value T
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&value); err != nil {
+			skipped[path] = err
+			continue
+		}
+		entries = append(entries, corpusEntry[T]{path, value})
+	}
+	return entries, skipped, nil
+}
+
+// saveCorpusEntry saves the given value as a JSON file in the given directory, named after the hash of its content,
+// so that saving the same value twice does not duplicate it. It returns the path of the file.
+func saveCorpusEntry(dir string, value any) (string, error) {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	path := filepath.Join(dir, hex.EncodeToString(sum[:8]))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	return path, os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,93 @@
+package gen
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestForAllSavesAndReplaysCounterexamples(t *testing.T) {
+	dir := t.TempDir()
+	property := func(p Person) bool { return p.Age < 500 }
+
+	ft := &fakeT{name: "TestPersons/adults"}
+	ForAll(ft, Map(Between(0, 1000), func(age int) Person { return Person{"John", age} }), property, CorpusDir(dir))
+	if !ft.failed {
+		t.Fatal("ForAll did not fail for a property that does not hold")
+	}
+
+	files, err := os.ReadDir(filepath.Join(dir, "TestPersons", "adults", "gen.Person"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("ForAll did not save the counterexample to the corpus, files: %v, err: %v", files, err)
+	}
+
+	// The generator cannot generate the counterexample anymore, so only the corpus can make the test fail.
+	ft = &fakeT{name: "TestPersons/adults"}
+	ForAll(ft, Only(Person{"John", 1}), property, CorpusDir(dir))
+	if !ft.failed || !strings.Contains(ft.message, "corpus entry") || !strings.Contains(ft.message, "Age:500") {
+		t.Errorf("ForAll did not check the counterexample saved in the corpus first, message: %s", ft.message)
+	}
+}
+
+func TestForAllSkipsUndecodableCorpusEntries(t *testing.T) {
+	dir := t.TempDir()
+	testDir := filepath.Join(dir, "TestNumbers", "int")
+	if err := os.MkdirAll(testDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(testDir, "entry"), []byte(`"not a number"`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ft := &fakeT{name: "TestNumbers"}
+	ForAll(ft, Between(0, 10), func(n int) bool { return n <= 10 }, CorpusDir(dir))
+	if ft.failed {
+		t.Errorf("ForAll failed because of an undecodable corpus entry: %s", ft.message)
+	}
+}
+
+func TestForAllWithoutCorpus(t *testing.T) {
+	ft := &fakeT{name: "TestNoCorpus"}
+	ForAll(ft, Between(0, 10), func(n int) bool { return n < 5 }, CorpusDir(""))
+	if !ft.failed {
+		t.Fatal("ForAll did not fail for a property that does not hold")
+	}
+	if _, err := os.Stat(filepath.Join(defaultCorpusDir, "TestNoCorpus")); !os.IsNotExist(err) {
+		t.Errorf("ForAll saved the counterexample although the corpus was disabled")
+	}
+}
+
+func TestForAllKeepsTheCorpusOfEachTypeApart(t *testing.T) {
+	dir := t.TempDir()
+	ft := &fakeT{name: "TestMixed"}
+	ForAll(ft, Only(Person{"John", 500}), func(p Person) bool { return p.Age < 500 }, CorpusDir(dir))
+	if !ft.failed {
+		t.Fatal("ForAll did not fail for a property that does not hold")
+	}
+
+	// The entries of Person can be decoded into TestPerson, whose Surname would be left empty.
+	ft = &fakeT{name: "TestMixed"}
+	ForAll(ft, Only(TestPerson{Name: "John", Age: 1}), func(p TestPerson) bool { return p.Age < 500 }, CorpusDir(dir))
+	if ft.failed {
+		t.Errorf("ForAll replayed the corpus entry of another type: %s", ft.message)
+	}
+}
+
+func TestForAllSkipsCorpusEntriesWithUnknownFields(t *testing.T) {
+	dir := t.TempDir()
+	testDir := filepath.Join(dir, "TestPersons", "gen.Person")
+	if err := os.MkdirAll(testDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	entry := []byte(`{"Name": "John", "Age": 500, "Email": "john@example.com"}`)
+	if err := os.WriteFile(filepath.Join(testDir, "entry"), entry, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ft := &fakeT{name: "TestPersons"}
+	ForAll(ft, Only(Person{"John", 1}), func(p Person) bool { return p.Age < 500 }, CorpusDir(dir))
+	if ft.failed || !strings.Contains(strings.Join(ft.logs, "\n"), "unknown field") {
+		t.Errorf("ForAll did not skip the corpus entry with an unknown field, message: %s, logs: %v", ft.message, ft.logs)
+	}
+}
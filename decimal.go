@@ -0,0 +1,44 @@
+package gen
+
+import (
+	"math/big"
+	"strings"
+)
+
+// Decimal is a fixed-scale decimal value, the value of which is Amount * 10**-Scale (e.g., {12345, 2} is 123.45),
+// which is how money is usually represented.
+type Decimal struct {
+	Amount int64
+	Scale  uint8
+}
+
+// String returns the decimal representation of the value, with exactly Scale fractional digits.
+func (d Decimal) String() string {
+	digits := big.NewInt(d.Amount).String()
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	scale := int(d.Scale)
+	if scale == 0 {
+		return sign + digits
+	}
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+}
+
+// Rat returns the exact value of the decimal as a rational number.
+func (d Decimal) Rat() *big.Rat {
+	denom := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.Scale)), nil)
+	return new(big.Rat).SetFrac(big.NewInt(d.Amount), denom)
+}
+
+// DecimalGen generates decimals of the given scale, using the given generator for their amounts
+// (i.e., their values in the smallest unit):
+//
+//	priceGen := gen.DecimalGen(gen.Between(int64(0), 1_000_000), 2) // 0.00 to 10000.00
+func DecimalGen(amounts Gen[int64], scale uint8) Gen[Decimal] {
+	return Map(amounts, func(amount int64) Decimal { return Decimal{amount, scale} })
+}
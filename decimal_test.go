@@ -0,0 +1,32 @@
+package gen
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestDecimalString(t *testing.T) {
+	cases := map[Decimal]string{
+		{12345, 2}:          "123.45",
+		{-5, 2}:             "-0.05",
+		{7, 0}:              "7",
+		{0, 3}:              "0.000",
+		{math.MinInt64, 18}: "-9.223372036854775808",
+		{math.MaxInt64, 20}: "0.09223372036854775807",
+	}
+	for decimal, expected := range cases {
+		if actual := decimal.String(); actual != expected {
+			t.Errorf("unexpected string of %#v, expected: %s, got: %s", decimal, expected, actual)
+		}
+	}
+}
+
+func TestDecimalGen(t *testing.T) {
+	max := big.NewRat(10000, 1)
+	for _, value := range GenerateN(DecimalGen(Between(int64(0), 1_000_000), 2), 100) {
+		if value.Scale != 2 || value.Rat().Sign() < 0 || value.Rat().Cmp(max) > 0 {
+			t.Fatalf("DecimalGen generated an unexpected value: %v", value)
+		}
+	}
+}
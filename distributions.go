@@ -0,0 +1,269 @@
+package gen
+
+import (
+	"fmt"
+	"math"
+)
+
+// maxRejections is the number of times the rejection samplers retry, before falling back to a typical value.
+// The samplers reject values very rarely, but the zero choices replayed while shrinking might be rejected forever.
+const maxRejections = 100
+
+type distribution[T Numeric] struct {
+	sample func(r *Rand) float64
+}
+
+func (d distribution[T]) Generate() T { return d.generate(defaultRand) }
+
+func (d distribution[T]) generate(r *Rand) T { return saturate[T](d.sample(r)) }
+
+// saturate converts the given value to T, rounding it for integer types,
+// and clamping it into the domain of T instead of overflowing.
+func saturate[T Numeric](x float64) T {
+	min, max := numericBounds[T]()
+	switch {
+	case x != x:
+		return 0
+	case x <= float64(min):
+		return min
+	case x >= float64(max):
+		return max
+	case isFloat[T]():
+		return T(x)
+	default:
+		return T(math.Round(x))
+	}
+}
+
+// openUnit makes a choice within (0, 1], which is safe to take the logarithm of.
+func (r *Rand) openUnit() float64 {
+	return 1 - r.float64()
+}
+
+// standardNormal samples the standard normal distribution using the Box-Muller transform.
+func standardNormal(r *Rand) float64 {
+	u1, u2 := r.openUnit(), r.float64()
+	return math.Sqrt(-2*math.Log(u1)) * math.Cos(2*math.Pi*u2)
+}
+
+// standardGamma samples the gamma distribution with the given shape and a scale of 1,
+// using the method of Marsaglia and Tsang.
+func standardGamma(r *Rand, shape float64) float64 {
+	if shape < 1 {
+		return standardGamma(r, shape+1) * math.Pow(r.openUnit(), 1/shape)
+	}
+	d := shape - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for i := 0; i < maxRejections; i++ {
+		x := standardNormal(r)
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := r.float64()
+		if u < 1-0.0331*x*x*x*x || math.Log(u) < 0.5*x*x+d*(1-v+math.Log(v)) {
+			return d * v
+		}
+	}
+	return d
+}
+
+// poisson samples the poisson distribution by multiplying uniform values for small lambdas,
+// and using the transformed rejection method of Hörmann (PTRS) for the large ones.
+func poisson(r *Rand, lambda float64) float64 {
+	if lambda < 30 {
+		limit, p := math.Exp(-lambda), 1.0
+		k := 0.0
+		for ; k < 10*lambda+maxRejections; k++ {
+			p *= r.float64()
+			if p <= limit {
+				break
+			}
+		}
+		return k
+	}
+
+	sqrtLambda, logLambda := math.Sqrt(lambda), math.Log(lambda)
+	b := 0.931 + 2.53*sqrtLambda
+	a := -0.059 + 0.02483*b
+	invAlpha := 1.1239 + 1.1328/(b-3.4)
+	vr := 0.9277 - 3.6224/(b-2)
+	for i := 0; i < maxRejections; i++ {
+		u, v := r.float64()-0.5, r.float64()
+		us := 0.5 - math.Abs(u)
+		if us == 0 {
+			continue
+		}
+		k := math.Floor((2*a/us+b)*u + lambda + 0.43)
+		if us >= 0.07 && v <= vr {
+			return k
+		}
+		if k < 0 || (us < 0.013 && v > us) {
+			continue
+		}
+		logFactorial, _ := math.Lgamma(k + 1)
+		if math.Log(v)+math.Log(invAlpha)-math.Log(a/(us*us)+b) <= -lambda+k*logLambda-logFactorial {
+			return k
+		}
+	}
+	return math.Floor(lambda)
+}
+
+// binomial samples the binomial distribution by inverting its cumulative distribution function,
+// and approximates it with a normal distribution when the expected number of successes is large.
+func binomial(r *Rand, n uint64, p float64) float64 {
+	if p > 0.5 {
+		return float64(n) - binomial(r, n, 1-p)
+	}
+	trials := float64(n)
+	if trials*p >= 30 {
+		x := math.Round(trials*p + math.Sqrt(trials*p*(1-p))*standardNormal(r))
+		return math.Max(0, math.Min(trials, x))
+	}
+
+	q := 1 - p
+	s, a := p/q, (trials+1)*p/q
+	prob := math.Pow(q, trials)
+	u := r.float64()
+	x := 0.0
+	for u > prob && x < trials {
+		u -= prob
+		x++
+		prob *= a/x - s
+	}
+	return x
+}
+
+// zipf samples the zipf distribution using the rejection-inversion method, as math/rand does.
+type zipf struct {
+	imax, v, q, s           float64
+	oneMinusQ, oneMinusQInv float64
+	hxm, hx0MinusHxm        float64
+}
+
+func newZipf(s, v float64, imax uint64) *zipf {
+	z := &zipf{imax: float64(imax), v: v, q: s, oneMinusQ: 1 - s, oneMinusQInv: 1 / (1 - s)}
+	z.hxm = z.h(z.imax + 0.5)
+	z.hx0MinusHxm = z.h(0.5) - math.Exp(math.Log(z.v)*(-z.q)) - z.hxm
+	z.s = 1 - z.hinv(z.h(1.5)-math.Exp(-z.q*math.Log(z.v+1)))
+	return z
+}
+
+func (z *zipf) h(x float64) float64 {
+	return math.Exp(z.oneMinusQ*math.Log(z.v+x)) * z.oneMinusQInv
+}
+
+func (z *zipf) hinv(x float64) float64 {
+	return math.Exp(z.oneMinusQInv*math.Log(z.oneMinusQ*x)) - z.v
+}
+
+func (z *zipf) sample(r *Rand) float64 {
+	k := 0.0
+	for i := 0; i < maxRejections; i++ {
+		// The simplest choice samples the most probable value, which is 0.
+		ur := z.hxm + r.openUnit()*z.hx0MinusHxm
+		x := z.hinv(ur)
+		k = math.Floor(x + 0.5)
+		if k-x <= z.s || ur >= z.h(k+0.5)-math.Exp(-math.Log(k+z.v)*z.q) {
+			break
+		}
+	}
+	return math.Max(0, math.Min(z.imax, k))
+}
+
+func requirePositive(name string, values ...float64) {
+	for _, value := range values {
+		if !(value > 0) || math.IsInf(value, 1) {
+			panic(fmt.Errorf("gen: %s parameters must be positive and finite, got: %v", name, values))
+		}
+	}
+}
+
+func requireProbability(name string, p float64) {
+	if !(p > 0 && p <= 1) {
+		panic(fmt.Errorf("gen: %s probability must be within (0, 1], got: %v", name, p))
+	}
+}
+
+// Normal generates values of the normal (gaussian) distribution with the given mean and standard deviation.
+// Like all the other distributions, the values are rounded for integer types, and are clamped into the domain of T
+// (see Clamp for clamping them into a range):
+//
+//	latencyGen := gen.Clamp(gen.Normal[int](120, 30), 0, 1000)
+func Normal[T Numeric](mean, stddev float64) Gen[T] {
+	if stddev < 0 || math.IsNaN(stddev) {
+		panic(fmt.Errorf("gen: Normal standard deviation must not be negative, got: %v", stddev))
+	}
+	return distribution[T]{func(r *Rand) float64 { return mean + stddev*standardNormal(r) }}
+}
+
+// LogNormal generates values whose logarithm is normally distributed with the given mean and standard deviation.
+func LogNormal[T Numeric](mu, sigma float64) Gen[T] {
+	if sigma < 0 || math.IsNaN(sigma) {
+		panic(fmt.Errorf("gen: LogNormal sigma must not be negative, got: %v", sigma))
+	}
+	return distribution[T]{func(r *Rand) float64 { return math.Exp(mu + sigma*standardNormal(r)) }}
+}
+
+// Exponential generates values of the exponential distribution with the given rate (the inverse of its mean),
+// e.g., the time between the events of a poisson process.
+func Exponential[T Numeric](rate float64) Gen[T] {
+	requirePositive("Exponential", rate)
+	return distribution[T]{func(r *Rand) float64 { return -math.Log1p(-r.float64()) / rate }}
+}
+
+// Poisson generates values of the poisson distribution with the given mean,
+// e.g., the number of events occurring within a fixed interval.
+func Poisson[T Numeric](lambda float64) Gen[T] {
+	requirePositive("Poisson", lambda)
+	return distribution[T]{func(r *Rand) float64 { return poisson(r, lambda) }}
+}
+
+// Zipf generates values within [0, imax] of the zipf distribution, in which the probability of k is proportional
+// to (v + k) ** (-s), e.g., the popularity of the items of a catalog. s must be greater than 1, and v at least 1.
+func Zipf[T Numeric](s, v float64, imax uint64) Gen[T] {
+	if !(s > 1) || !(v >= 1) {
+		panic(fmt.Errorf("gen: Zipf requires s > 1 and v >= 1, got s: %v and v: %v", s, v))
+	}
+	z := newZipf(s, v, imax)
+	return distribution[T]{z.sample}
+}
+
+// Geometric generates the number of failures before the first success in a series of trials,
+// each of which succeeds with the given probability.
+func Geometric[T Numeric](p float64) Gen[T] {
+	requireProbability("Geometric", p)
+	if p == 1 {
+		return Only(T(0))
+	}
+	return distribution[T]{func(r *Rand) float64 { return math.Floor(math.Log1p(-r.float64()) / math.Log1p(-p)) }}
+}
+
+// Binomial generates the number of successes in n trials, each of which succeeds with the given probability.
+func Binomial[T Numeric](n uint64, p float64) Gen[T] {
+	if !(p >= 0 && p <= 1) {
+		panic(fmt.Errorf("gen: Binomial probability must be within [0, 1], got: %v", p))
+	}
+	return distribution[T]{func(r *Rand) float64 { return binomial(r, n, p) }}
+}
+
+// Beta generates values within [0, 1] of the beta distribution with the given shape parameters,
+// e.g., ratios and probabilities. It's mostly meaningful for float types.
+func Beta[T Numeric](alpha, beta float64) Gen[T] {
+	requirePositive("Beta", alpha, beta)
+	return distribution[T]{func(r *Rand) float64 {
+		x, y := standardGamma(r, alpha), standardGamma(r, beta)
+		if x+y == 0 {
+			return alpha / (alpha + beta)
+		}
+		return x / (x + y)
+	}}
+}
+
+// Clamp clamps the values generated by the given generator into the given range (both ends of which are inclusive),
+// the order of the parameters doesn't matter.
+func Clamp[T Numeric](g Gen[T], min, max T) Gen[T] {
+	low, high := numericMin(min, max), numericMax(min, max)
+	return Map(g, func(value T) T { return numericMax(low, numericMin(high, value)) })
+}
@@ -0,0 +1,102 @@
+package gen
+
+import (
+	"math"
+	"testing"
+)
+
+func meanOf[T Numeric](values []T) float64 {
+	sum := 0.0
+	for _, value := range values {
+		sum += float64(value)
+	}
+	return sum / float64(len(values))
+}
+
+func TestDistributionMeans(t *testing.T) {
+	const n = 20000
+	cases := []struct {
+		name     string
+		values   []float64
+		expected float64
+	}{
+		{"normal", GenerateNWith(Normal[float64](10, 2), NewRand(1), n), 10},
+		{"log-normal", GenerateNWith(LogNormal[float64](0, 0.5), NewRand(2), n), math.Exp(0.125)},
+		{"exponential", GenerateNWith(Exponential[float64](4), NewRand(3), n), 0.25},
+		{"poisson", GenerateNWith(Poisson[float64](3), NewRand(4), n), 3},
+		{"poisson-large", GenerateNWith(Poisson[float64](500), NewRand(5), n), 500},
+		{"geometric", GenerateNWith(Geometric[float64](0.25), NewRand(6), n), 3},
+		{"binomial", GenerateNWith(Binomial[float64](20, 0.3), NewRand(7), n), 6},
+		{"binomial-large", GenerateNWith(Binomial[float64](1000, 0.6), NewRand(8), n), 600},
+		{"beta", GenerateNWith(Beta[float64](2, 5), NewRand(9), n), 2.0 / 7},
+		{"beta-small-shapes", GenerateNWith(Beta[float64](0.5, 0.5), NewRand(10), n), 0.5},
+	}
+
+	for _, c := range cases {
+		if actual := meanOf(c.values); math.Abs(actual-c.expected) > 0.05*c.expected {
+			t.Errorf("%s distribution has an unexpected mean, expected: %v, got: %v", c.name, c.expected, actual)
+		}
+	}
+}
+
+func TestZipf(t *testing.T) {
+	counts := make(map[int]int)
+	for _, value := range GenerateNWith(Zipf[int](1.5, 1, 100), NewRand(1), 10000) {
+		if value < 0 || value > 100 {
+			t.Fatalf("Zipf generated a value out of [0, 100]: %d", value)
+		}
+		counts[value]++
+	}
+	if counts[0] <= counts[1] || counts[1] <= counts[10] {
+		t.Errorf("Zipf did not generate smaller values more frequently: %v", counts)
+	}
+}
+
+func TestDistributionsSaturateIntoTheDomainOfTheType(t *testing.T) {
+	var sawMin, sawMax bool
+	for _, value := range GenerateN(Normal[uint8](128, 10000), 100) {
+		sawMin = sawMin || value == 0
+		sawMax = sawMax || value == math.MaxUint8
+	}
+	if !sawMin || !sawMax {
+		t.Errorf("Normal did not saturate into the domain of uint8")
+	}
+}
+
+func TestClamp(t *testing.T) {
+	for _, value := range GenerateN(Clamp(Normal[int](0, 100), 10, -10), 1000) {
+		if value < -10 || value > 10 {
+			t.Fatalf("Clamp generated a value out of its range: %d", value)
+		}
+	}
+}
+
+func TestDistributionsWithInvalidParameters(t *testing.T) {
+	constructors := map[string]func(){
+		"normal":      func() { Normal[float64](0, -1) },
+		"exponential": func() { Exponential[float64](0) },
+		"poisson":     func() { Poisson[int](-1) },
+		"zipf":        func() { Zipf[int](1, 1, 10) },
+		"geometric":   func() { Geometric[int](0) },
+		"binomial":    func() { Binomial[int](10, 1.5) },
+		"beta":        func() { Beta[float64](1, 0) },
+	}
+	for name, constructor := range constructors {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic for invalid parameters", name)
+				}
+			}()
+			constructor()
+		}()
+	}
+}
+
+func TestDistributionsShrink(t *testing.T) {
+	err := Check(Poisson[int](20), func(n int) bool { return n < 15 })
+
+	if actual := counterexampleOf(t, err); actual != 15 {
+		t.Errorf("Poisson was not shrunk to the smallest counterexample, expected: 15, got: %v", actual)
+	}
+}
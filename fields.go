@@ -0,0 +1,99 @@
+package gen
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// fieldNode is a node of the trie of the field paths, which the generators are bound to.
+type fieldNode struct {
+	children map[string]*fieldNode
+	gen      *WrappedGen
+}
+
+// child returns the node of the field with the given name, it's nil if there are no generators bound within the field.
+func (n *fieldNode) child(name string) *fieldNode {
+	if n == nil {
+		return nil
+	}
+	return n.children[name]
+}
+
+// bound reports whether there is a generator bound to the path of the node.
+func (n *fieldNode) bound() bool {
+	return n != nil && n.gen != nil
+}
+
+type fieldBinding struct {
+	path string
+	gen  *WrappedGen
+}
+
+// Field binds the given generator to the field at the given dotted path (e.g., "Address.ZipCode"), which takes
+// precedence over the tags and the wrapped generators. The path goes through the elements of slices, arrays and
+// pointers, and the values of maps, so "Orders.Total" is the Total field of each one of the Orders.
+// The fields of embedded structs are bound through the name of the embedded struct (e.g., "address.Street"), even if
+// it's unexported, rather than as promoted fields (e.g., "Street"):
+//
+//	personGen, err := gen.Infer[Person](
+//		gen.Field("Name", gen.OneOf("John", "Jack")),
+//		gen.Field("Address.ZipCode", gen.MustRegex(`[0-9]{5}`)),
+//	)
+//
+// Infer returns an error if there's no such field, or if the field is not of type T.
+func Field[T any](path string, g Gen[T]) InferOption {
+	wrapped := Wrap(g)
+	return inferOptionFunc(func(c *inferConfig) {
+		c.bindings = append(c.bindings, fieldBinding{path, wrapped})
+	})
+}
+
+// bindFields resolves the paths of the bound generators within the given type, and adds them to the trie.
+func (c *inferConfig) bindFields(t reflect.Type) error {
+	for _, binding := range c.bindings {
+		node := c.fields
+		current := t
+		names := strings.Split(binding.path, ".")
+		for i, name := range names {
+			current = fieldContainer(current)
+			if current.Kind() != reflect.Struct {
+				return fmt.Errorf("gen: cannot bind a generator to %s in %s, %s is not a struct", binding.path, t, current)
+			}
+			field, found := current.FieldByName(name)
+			// The unexported embedded structs are not generated, but their exported fields are, so they can be gone through.
+			throughEmbedded := field.Anonymous && field.Type.Kind() == reflect.Struct && i < len(names)-1
+			if !found || len(field.Index) != 1 || !field.IsExported() && !throughEmbedded {
+				return fmt.Errorf("gen: cannot bind a generator to %s in %s, there's no exported field %s in %s",
+					binding.path, t, name, current)
+			}
+			if node.children == nil {
+				node.children = make(map[string]*fieldNode)
+			}
+			if node.children[name] == nil {
+				node.children[name] = &fieldNode{}
+			}
+			node = node.children[name]
+			current = field.Type
+		}
+		if !binding.gen.tpe.AssignableTo(current) {
+			return fmt.Errorf("gen: cannot bind a generator of %s to %s in %s, which is of type %s",
+				binding.gen.tpe, binding.path, t, current)
+		}
+		node.gen = binding.gen
+	}
+	return nil
+}
+
+// fieldContainer returns the type which contains the fields of the values of the given type,
+// which are the elements of pointers, slices and arrays, and the values of maps.
+func fieldContainer(t reflect.Type) reflect.Type {
+	for {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		default:
+			return t
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package gen
+
+import "fmt"
+
+var defaultMaxDiscards = 100
+
+// FilterError is what a filtered generator panics with, when it discards more values than it's allowed to in a row.
+// The property runner recovers from it, and discards the iteration instead of failing.
+type FilterError struct {
+	// MaxDiscards is the number of values the generator was allowed to discard in a row.
+	MaxDiscards int
+}
+
+func (e *FilterError) Error() string {
+	return fmt.Sprintf("filter discarded %d values in a row, the predicate might be too strict", e.MaxDiscards+1)
+}
+
+type filter[T any] struct {
+	underlying  Gen[T]
+	predicate   func(T) bool
+	maxDiscards int
+}
+
+func (f *filter[T]) Generate() T { return f.generate(defaultRand) }
+
+func (f *filter[T]) generate(r *Rand) T {
+	for i := 0; i <= f.maxDiscards; i++ {
+		if value := GenerateWith(f.underlying, r); f.predicate(value) {
+			return value
+		}
+	}
+	panic(&FilterError{f.maxDiscards})
+}
+
+// Shrink shrinks the value using the underlying generator if it's a Shrinker, keeping only the candidates which satisfy the predicate.
+func (f *filter[T]) Shrink(value T) []T {
+	shrinker, ok := shrinkerOf(f.underlying)
+	if !ok {
+		return nil
+	}
+	var candidates []T
+	for _, candidate := range shrinker.Shrink(value) {
+		if f.predicate(candidate) {
+			candidates = append(candidates, candidate)
+		}
+	}
+	return candidates
+}
+
+// SuchThat creates a generator which only generates the values of the given generator that satisfy the predicate.
+// It retries up to maxDiscards times for each value, and then panics with a *FilterError, which the property runner
+// recovers from by discarding the iteration. Prefer generators that generate valid values by construction,
+// and use SuchThat for predicates which are rarely false.
+func SuchThat[T any](g Gen[T], predicate func(T) bool, maxDiscards int) Gen[T] {
+	if maxDiscards < 0 {
+		maxDiscards = 0
+	}
+	return &filter[T]{g, predicate, maxDiscards}
+}
+
+// Filter is SuchThat, with up to 100 discards for each value.
+func Filter[T any](g Gen[T], predicate func(T) bool) Gen[T] {
+	return SuchThat(g, predicate, defaultMaxDiscards)
+}
+
+// tryGenerate generates a value using the given generator, returning the *FilterError or the *ExhaustedError
+// that it panics with, if any.
+func tryGenerate[T any](g Gen[T], r *Rand) (value T, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			switch recoveredErr := recovered.(type) {
+			case *FilterError:
+				err = recoveredErr
+			case *ExhaustedError:
+				err = recoveredErr
+			default:
+				panic(recovered)
+			}
+		}
+	}()
+	return GenerateWith(g, r), nil
+}
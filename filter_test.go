@@ -0,0 +1,82 @@
+package gen
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type loggingT struct {
+	fakeT
+	logs []string
+}
+
+func (l *loggingT) Logf(format string, args ...any) {
+	l.logs = append(l.logs, format)
+}
+
+func TestFilter(t *testing.T) {
+	evens := Filter(Between(0, 1000), func(n int) bool { return n%2 == 0 })
+
+	for _, value := range GenerateN(evens, 100) {
+		if value%2 != 0 {
+			t.Fatalf("Filter generated a value which does not satisfy the predicate: %d", value)
+		}
+	}
+}
+
+func TestSuchThatPanicsWithFilterError(t *testing.T) {
+	g := SuchThat(Between(0, 10), func(n int) bool { return n > 100 }, 5)
+
+	defer func() {
+		filterErr, ok := recover().(*FilterError)
+		if !ok || filterErr.MaxDiscards != 5 {
+			t.Errorf("SuchThat did not panic with the expected FilterError, got: %v", filterErr)
+		}
+	}()
+	g.Generate()
+}
+
+func TestCheckGivesUpWhenTooManyIterationsAreDiscarded(t *testing.T) {
+	g := SuchThat(Between(0, 10), func(n int) bool { return n > 100 }, 5)
+
+	err := Check(g, func(int) bool { return true }, Iterations(10), MaxDiscardRatio(2))
+
+	var gaveUp *GaveUpError
+	if !errors.As(err, &gaveUp) {
+		t.Fatalf("Check did not give up, got: %v", err)
+	}
+	if gaveUp.Discarded != 21 || gaveUp.Passed != 0 {
+		t.Errorf("unexpected number of discarded or passed iterations: %+v", gaveUp)
+	}
+}
+
+func TestForAllReportsDiscardedIterations(t *testing.T) {
+	// Half of the values do not satisfy the predicate, and the filter only tries twice,
+	// so about a quarter of the iterations are discarded.
+	g := SuchThat(Between(0, 100), func(n int) bool { return n < 50 }, 1)
+
+	lt := &loggingT{}
+	ForAll(lt, g, func(n int) bool { return n < 50 }, Iterations(1000))
+
+	if lt.failed {
+		t.Fatalf("ForAll failed: %s", lt.message)
+	}
+	if len(lt.logs) != 1 || !strings.Contains(lt.logs[0], "discarded") {
+		t.Errorf("ForAll did not report the discarded iterations, logs: %v", lt.logs)
+	}
+}
+
+func TestFilteredGeneratorsAreShrunkWithinThePredicate(t *testing.T) {
+	odds := Filter(Between(0, 1000), func(n int) bool { return n%2 == 1 })
+
+	err := Check(odds, func(n int) bool { return n < 100 })
+
+	var ce *CheckError
+	if !errors.As(err, &ce) {
+		t.Fatalf("expected a CheckError, got: %v", err)
+	}
+	if actual := ce.Counterexample.(int); actual%2 != 1 || actual < 100 || actual > ce.Original.(int) {
+		t.Errorf("filtered generator was not shrunk within its predicate, got: %d (from %v)", actual, ce.Original)
+	}
+}
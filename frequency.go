@@ -0,0 +1,112 @@
+package gen
+
+import "fmt"
+
+// Weighted pairs a value with its weight, the chance of a weighted value being picked is proportional to its weight.
+type Weighted[T any] struct {
+	Weight uint
+	Value  T
+}
+
+// aliasTable picks indices according to their weights in constant time, using Vose's alias method.
+type aliasTable struct {
+	probabilities []float64
+	aliases       []int
+}
+
+func newAliasTable(weights []uint) *aliasTable {
+	n := len(weights)
+	total := 0.0
+	for _, w := range weights {
+		total += float64(w)
+	}
+	if n == 0 || total == 0 {
+		panic(fmt.Errorf("gen: weighted choice needs at least one positive weight, got: %v", weights))
+	}
+
+	table := &aliasTable{make([]float64, n), make([]int, n)}
+	scaled := make([]float64, n)
+	var small, large []int
+	for i, w := range weights {
+		scaled[i] = float64(w) * float64(n) / total
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+	for len(small) > 0 && len(large) > 0 {
+		s, l := small[len(small)-1], large[len(large)-1]
+		small, large = small[:len(small)-1], large[:len(large)-1]
+		table.probabilities[s], table.aliases[s] = scaled[s], l
+		scaled[l] = scaled[l] + scaled[s] - 1
+		if scaled[l] < 1 {
+			small = append(small, l)
+		} else {
+			large = append(large, l)
+		}
+	}
+	// What's left is (up to floating point errors) exactly 1.
+	for _, i := range append(small, large...) {
+		table.probabilities[i], table.aliases[i] = 1, i
+	}
+	return table
+}
+
+func (t *aliasTable) pick(r *Rand) int {
+	i := r.intn(len(t.probabilities))
+	if r.float64() < t.probabilities[i] {
+		return i
+	}
+	return t.aliases[i]
+}
+
+type weightedOneOf[T any] struct {
+	values []T
+	table  *aliasTable
+}
+
+func (w *weightedOneOf[T]) Generate() T { return w.generate(defaultRand) }
+
+func (w *weightedOneOf[T]) generate(r *Rand) T {
+	return w.values[w.table.pick(r)]
+}
+
+// WeightedOneOf picks out a value among the given values, with chances proportional to the given weights.
+// Values with zero weight are never picked. It panics if the number of weights is not the same as the number of values,
+// or if none of the weights is positive.
+//
+//	statusGen := gen.WeightedOneOf([]string{"active", "banned"}, []uint{90, 10})
+func WeightedOneOf[T any](values []T, weights []uint) Gen[T] {
+	if len(values) != len(weights) {
+		panic(fmt.Errorf("gen: WeightedOneOf got %d values and %d weights", len(values), len(weights)))
+	}
+	return &weightedOneOf[T]{values, newAliasTable(weights)}
+}
+
+type frequency[T any] struct {
+	gens  []Gen[T]
+	table *aliasTable
+}
+
+func (f *frequency[T]) Generate() T { return f.generate(defaultRand) }
+
+func (f *frequency[T]) generate(r *Rand) T {
+	return GenerateWith(f.gens[f.table.pick(r)], r)
+}
+
+// Frequency picks out a generator among the given weighted generators, with chances proportional to their weights,
+// and uses it to generate the value. It panics if none of the weights is positive.
+//
+//	ageGen := gen.Frequency(
+//		gen.Weighted[gen.Gen[int]]{Weight: 9, Value: gen.Between(18, 65)},
+//		gen.Weighted[gen.Gen[int]]{Weight: 1, Value: gen.Only(-1)},
+//	)
+func Frequency[T any](pairs ...Weighted[Gen[T]]) Gen[T] {
+	gens := make([]Gen[T], len(pairs))
+	weights := make([]uint, len(pairs))
+	for i, pair := range pairs {
+		gens[i], weights[i] = pair.Value, pair.Weight
+	}
+	return &frequency[T]{gens, newAliasTable(weights)}
+}
@@ -0,0 +1,71 @@
+package gen
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWeightedOneOfDistribution(t *testing.T) {
+	g := WeightedOneOf([]string{"active", "banned", "deleted"}, []uint{90, 10, 0})
+	n := 20000
+
+	counts := make(map[string]int)
+	for _, value := range GenerateNWith(g, NewRand(42), uint(n)) {
+		counts[value]++
+	}
+
+	if counts["deleted"] != 0 {
+		t.Errorf("WeightedOneOf picked a value with zero weight %d times", counts["deleted"])
+	}
+	if ratio := float64(counts["active"]) / float64(n); math.Abs(ratio-0.9) > 0.02 {
+		t.Errorf("WeightedOneOf did not respect the weights, expected ratio: 0.9, got: %f", ratio)
+	}
+}
+
+func TestFrequency(t *testing.T) {
+	g := Frequency(
+		Weighted[Gen[int]]{Weight: 3, Value: Between(0, 10)},
+		Weighted[Gen[int]]{Weight: 1, Value: Only(-1)},
+	)
+	n := 20000
+
+	negatives := 0
+	for _, value := range GenerateNWith(g, NewRand(7), uint(n)) {
+		if value == -1 {
+			negatives++
+		} else if !isBetween(value, 0, 10) {
+			t.Fatalf("Frequency generated a value which none of its generators can generate: %d", value)
+		}
+	}
+	if ratio := float64(negatives) / float64(n); math.Abs(ratio-0.25) > 0.02 {
+		t.Errorf("Frequency did not respect the weights, expected ratio: 0.25, got: %f", ratio)
+	}
+}
+
+func TestWeightedChoiceWithInvalidWeights(t *testing.T) {
+	assertPanics := func(name string, f func()) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s did not panic", name)
+			}
+		}()
+		f()
+	}
+
+	assertPanics("zero weights", func() { WeightedOneOf([]int{1, 2}, []uint{0, 0}) })
+	assertPanics("mismatched weights", func() { WeightedOneOf([]int{1, 2}, []uint{1}) })
+	assertPanics("no generators", func() { Frequency[int]() })
+}
+
+func BenchmarkWeightedOneOf(b *testing.B) {
+	values := make([]int, 1000)
+	weights := make([]uint, 1000)
+	for i := range values {
+		values[i], weights[i] = i, uint(i%7+1)
+	}
+	g := WeightedOneOf(values, weights)
+
+	for i := 0; i < b.N; i++ {
+		g.Generate()
+	}
+}
@@ -0,0 +1,55 @@
+package gen
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"reflect"
+	"sync"
+)
+
+// DeterministicFuncs makes the functions generated by Infer deterministic, so that they return the same outputs
+// whenever they're called with the same inputs (like the functions generated using CoArbitrary in QuickCheck).
+// The inputs are told apart by their printed values (as in "%#v"), so pointers are the same inputs only if they
+// point to the same address.
+// By default, the functions return new outputs on each call, from a sequence which only depends on the choices
+// the function was generated from.
+//
+//	handlerGen, err := gen.Infer[Handler](gen.DeterministicFuncs())
+func DeterministicFuncs() InferOption {
+	return inferOptionFunc(func(c *inferConfig) { c.deterministicFuncs = true })
+}
+
+// funcValue generates a function of the given type, which generates its outputs with the given size when called.
+// Functions are safe for concurrent use, and their outputs are drawn from a Rand of their own, seeded by a single
+// choice of the given Rand, since they're called after their generation is done.
+func (c *inferConfig) funcValue(r *Rand, t reflect.Type, size int) reflect.Value {
+	seed := int64(r.draw(math.MaxUint64))
+	if c.deterministicFuncs {
+		return reflect.MakeFunc(t, func(args []reflect.Value) []reflect.Value {
+			h := fnv.New64a()
+			for _, arg := range args {
+				fmt.Fprintf(h, "%#v;", arg.Interface())
+			}
+			return c.funcOutputs(NewRand(seed^int64(h.Sum64())), t, size)
+		})
+	}
+
+	rnd := NewRand(seed)
+	var lock sync.Mutex
+	return reflect.MakeFunc(t, func([]reflect.Value) []reflect.Value {
+		lock.Lock()
+		defer lock.Unlock()
+		return c.funcOutputs(rnd, t, size)
+	})
+}
+
+// funcOutputs generates the outputs of a call to a function of the given type.
+func (c *inferConfig) funcOutputs(r *Rand, t reflect.Type, size int) []reflect.Value {
+	r.size = size
+	outputs := make([]reflect.Value, t.NumOut())
+	for i := range outputs {
+		outputs[i] = c.sizedValue(r, t.Out(i), size, nil)
+	}
+	return outputs
+}
@@ -34,8 +34,10 @@ type oneOf[T any] struct {
 	numChoices int
 }
 
-func (o *oneOf[T]) Generate() T {
-	return o.choices[random.Intn(o.numChoices)]
+func (o *oneOf[T]) Generate() T { return o.generate(defaultRand) }
+
+func (o *oneOf[T]) generate(r *Rand) T {
+	return o.choices[r.intn(o.numChoices)]
 }
 
 // OneOf picks out a value among those values that it's given.
@@ -47,50 +49,98 @@ func OneOf[T any](values ...T) Gen[T] {
 	return &oneOf[T]{values, len(values)}
 }
 
+type oneOfGen[T any] struct {
+	gens []Gen[T]
+}
+
+func (o *oneOfGen[T]) Generate() T { return o.generate(defaultRand) }
+
+func (o *oneOfGen[T]) generate(r *Rand) T {
+	return GenerateWith(o.gens[r.intn(len(o.gens))], r)
+}
+
+// OneOfGen picks out a generator among those generators that it's given, and uses it to generate the value.
+// It's the basis of generating union/sum types, e.g., mixing valid values with the invalid ones:
+//
+//	ageGen := gen.OneOfGen(gen.Between(0, 120), gen.Only(-1))
+//
+// If it's given only one generator, it returns that generator. See Frequency for picking generators with weights.
+func OneOfGen[T any](gens ...Gen[T]) Gen[T] {
+	if len(gens) == 0 {
+		panic("gen: OneOfGen needs at least one generator")
+	}
+	if len(gens) == 1 {
+		return gens[0]
+	}
+	return &oneOfGen[T]{gens}
+}
+
+// WeightedOneOfGen is the weighted variant of OneOfGen, it picks out the generators with chances proportional to
+// the given weights, just like Frequency does. It panics if the number of weights is not the same as the number of generators.
+func WeightedOneOfGen[T any](gens []Gen[T], weights []uint) Gen[T] {
+	if len(gens) != len(weights) {
+		panic(fmt.Errorf("gen: WeightedOneOfGen got %d generators and %d weights", len(gens), len(weights)))
+	}
+	pairs := make([]Weighted[Gen[T]], len(gens))
+	for i := range gens {
+		pairs[i] = Weighted[Gen[T]]{weights[i], gens[i]}
+	}
+	return Frequency(pairs...)
+}
+
 type between[T Numeric] struct {
 	min, max T
+
+	// edgeCases are generated instead of uniform values with the probability of edgeCaseRatio.
+	edgeCases     []T
+	edgeCaseRatio float64
+
+	// byRepresentation makes floats be drawn uniformly among their representations rather than their values,
+	// so that every magnitude within the whole domain is as likely to be generated.
+	byRepresentation bool
 }
 
-func (r *between[T]) Generate() T {
-	// todo, the below line causes subtraction overflow, fix it
-	switch diff := any(r.max - r.min).(type) {
-	case uint8:
-		return T(randUint8(diff) + uint8(r.min))
-	case uint16:
-		return T(randUint16(diff) + uint16(r.min))
-	case uint32:
-		return T(randUint32(diff) + uint32(r.min))
-	case uint64:
-		return T(randUint64(diff) + uint64(r.min))
-	case uint:
-		return T(randUint(diff) + uint(r.min))
-	case int8:
-		return T(randInt8(diff) + int8(r.min))
-	case int16:
-		return T(randInt16(diff) + int16(r.min))
-	case int32:
-		return T(randInt32(diff) + int32(r.min))
-	case int64:
-		return T(randInt64(diff) + int64(r.min))
-	case int:
-		return T(randInt(diff) + int(r.min))
-	case float32:
-		return T(randFloat32(diff) + float32(r.min))
-	case float64:
-		return T(randFloat64(diff) + float64(r.min))
-	default:
-		panic(fmt.Errorf("match error: unrecognized Numeric type %t", diff))
+func (r *between[T]) Generate() T { return r.generate(defaultRand) }
+
+func (r *between[T]) generate(rnd *Rand) T {
+	if r.edgeCaseRatio > 0 && rnd.float64() < r.edgeCaseRatio {
+		return r.edgeCases[rnd.intn(len(r.edgeCases))]
 	}
+	if r.byRepresentation {
+		return finiteFloat[T](rnd)
+	}
+	return uniformNumeric(rnd, r.min, r.max)
 }
 
-// Between generates values within the given range.
+// Between generates uniformly distributed values within the given range, both ends of which are inclusive.
+// Any range is supported, including the whole domain of the type (e.g., Between(math.MinInt64, math.MaxInt64)).
 // The order of the parameters doesn't actually matter, but it's more convenient to pass them properly.
-// If max equals min, it returns an Only generator
-func Between[T Numeric](min, max T) Gen[T] {
+// If max equals min, it returns an Only generator.
+// Uniform values rarely hit the boundaries of the range, which is where bugs usually hide, so the EdgeCases option
+// can be used to mix them in:
+//
+//	indexGen := gen.Between(0, 1023, gen.EdgeCases(0.2))
+func Between[T Numeric](min, max T, options ...NumericOption) Gen[T] {
 	if min == max {
 		return Only(min)
 	}
-	actualMin := numericMin(min, max)
-	actualMax := numericMax(min, max)
-	return &between[T]{actualMin, actualMax}
+	return newBetween(numericMin(min, max), numericMax(min, max), false, options)
+}
+
+// newBetween creates a between generator, if wholeDomain is set (and T is a float), the special IEEE values
+// (NaN and infinities) are generated as edge cases, and the values are drawn by their representation.
+func newBetween[T Numeric](min, max T, wholeDomain bool, options []NumericOption) *between[T] {
+	config := newNumericConfig(options)
+	cases := edgeCases(min, max)
+	wholeDomain = wholeDomain && isFloat[T]()
+	if wholeDomain {
+		cases = append(cases, specialFloats[T]()...)
+	}
+	return &between[T]{
+		min:              min,
+		max:              max,
+		edgeCases:        cases,
+		edgeCaseRatio:    config.edgeCaseRatio,
+		byRepresentation: wholeDomain,
+	}
 }
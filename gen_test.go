@@ -1,6 +1,7 @@
 package gen
 
 import (
+	"math"
 	"math/rand"
 	"reflect"
 	"testing"
@@ -35,6 +36,38 @@ func TestOneOf(t *testing.T) {
 	}
 }
 
+func TestOneOfGen(t *testing.T) {
+	g := OneOfGen(Between(0, 10), Only(-1))
+
+	seen := make(map[bool]bool)
+	for _, value := range GenerateN(g, 100) {
+		if value != -1 && !isBetween(value, 0, 10) {
+			t.Fatalf("OneOfGen returned %d which none of its generators can generate", value)
+		}
+		seen[value == -1] = true
+	}
+	if len(seen) != 2 {
+		t.Errorf("OneOfGen did not use all of its generators")
+	}
+}
+
+func TestOneOfGenWithOneGenerator(t *testing.T) {
+	g := Between(0, 10)
+	if OneOfGen(g) != g {
+		t.Errorf("OneOfGen did not return the only generator it was given")
+	}
+}
+
+func TestWeightedOneOfGen(t *testing.T) {
+	g := WeightedOneOfGen([]Gen[int]{Between(0, 10), Only(-1)}, []uint{1, 0})
+
+	for _, value := range GenerateN(g, 100) {
+		if value == -1 {
+			t.Fatal("WeightedOneOfGen used a generator with zero weight")
+		}
+	}
+}
+
 func isBetween(num, actualMin, actualMax int) bool {
 	return num >= actualMin && num <= actualMax
 }
@@ -65,6 +98,55 @@ func TestBetweenWithOnePossibility(t *testing.T) {
 	}
 }
 
+func TestBetweenIsInclusive(t *testing.T) {
+	seen := make(map[int]bool)
+	for _, value := range GenerateN(Between(0, 3), 200) {
+		seen[value] = true
+	}
+	if len(seen) != 4 {
+		t.Errorf("Between(0, 3) did not generate each of 0, 1, 2 and 3, got: %v", seen)
+	}
+}
+
+func TestBetweenCoversTheWholeDomain(t *testing.T) {
+	seen := make(map[int8]bool)
+	for _, value := range GenerateN(Between(int8(math.MinInt8), int8(math.MaxInt8)), 10000) {
+		seen[value] = true
+	}
+	if len(seen) != 256 {
+		t.Errorf("Between did not cover the whole domain of int8, generated %d distinct values", len(seen))
+	}
+
+	aboveMaxInt64 := false
+	for _, value := range GenerateN(ArbitraryUint64, 100) {
+		aboveMaxInt64 = aboveMaxInt64 || value > math.MaxInt64
+	}
+	if !aboveMaxInt64 {
+		t.Errorf("ArbitraryUint64 did not generate any values above max int64")
+	}
+
+	outOfHalfRange := false
+	for _, value := range GenerateN(ArbitraryInt64, 100) {
+		outOfHalfRange = outOfHalfRange || value < math.MinInt64/2 || value > math.MaxInt64/2
+	}
+	if !outOfHalfRange {
+		t.Errorf("ArbitraryInt64 did not generate any values out of half of its range")
+	}
+}
+
+func TestBetweenWithWideFloatRanges(t *testing.T) {
+	for _, value := range GenerateN(ArbitraryFloat64, 1000) {
+		if math.IsInf(value, 0) || math.IsNaN(value) {
+			t.Fatalf("ArbitraryFloat64 generated a non-finite value: %v", value)
+		}
+	}
+	for _, value := range GenerateN(Between(float32(-math.MaxFloat32), float32(math.MaxFloat32)), 1000) {
+		if math.IsInf(float64(value), 0) {
+			t.Fatalf("Between generated an infinite float32: %v", value)
+		}
+	}
+}
+
 func TestOneOfWithOnePossibility(t *testing.T) {
 	type Human struct{ Name string }
 	onlyPossibility := Human{"John"}
@@ -2,14 +2,46 @@ package gen
 
 import (
 	"math/rand"
+	"sync"
 	"time"
 )
 
-var random *rand.Rand
+// lockedSource is a rand.Source which is safe for concurrent use, just like the one behind math/rand's functions.
+type lockedSource struct {
+	lock sync.Mutex
+	src  rand.Source64
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Uint64() uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.src.Uint64()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.src.Seed(seed)
+}
+
+var globalSource = &lockedSource{src: rand.NewSource(0).(rand.Source64)}
+
+// random is the package-level random generator, it's safe for concurrent use.
+var random = rand.New(globalSource)
+
+// defaultRand is the Rand which generators draw their random choices from, when they're not given any.
+// It neither records nor replays choices, and it draws from random, so it's safe for concurrent use.
+var defaultRand = newRand(random)
 
 // Seed the random generator of the package
 func Seed(seed int64) {
-	random = rand.New(rand.NewSource(seed))
+	globalSource.Seed(seed)
 }
 
 func init() {
@@ -1,5 +1,10 @@
 package gen
 
+import (
+	"fmt"
+	"math"
+)
+
 // Numeric represents numeric types constraint.
 type Numeric interface {
 	uint8 | uint16 | uint32 | uint64 | uint | int8 | int16 | int32 | int64 | int | float32 | float64
@@ -19,54 +24,230 @@ func numericMax[T Numeric](a, b T) T {
 	return b
 }
 
-func randUint8(n uint8) uint8 {
-	return uint8(random.Int31n(int32(n)))
+// isFloat reports whether T is a floating point type.
+func isFloat[T Numeric]() bool {
+	switch any(T(0)).(type) {
+	case float32, float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// isSigned reports whether T can represent negative values.
+func isSigned[T Numeric]() bool {
+	return T(0)-1 < 0
 }
 
-func randUint16(n uint16) uint16 {
-	return uint16(random.Int31n(int32(n)))
+// uniformNumeric returns a uniformly distributed value within [min, max] (inclusive).
+// Integers are drawn as an offset from min within 64 bits, which cannot overflow since the range of any
+// integer type fits in a uint64, so the whole domain of every type can be covered.
+// The offsets are zig-zagged around the value closest to zero, so that simpler choices shrink the value towards zero.
+func uniformNumeric[T Numeric](r *Rand, min, max T) T {
+	switch {
+	case isFloat[T]():
+		return uniformFloat(r, min, max)
+	case isSigned[T]():
+		// The difference of the two's complement representations is the size of the range, modulo 2^64.
+		low := uint64(int64(min))
+		n, zero := uint64(int64(max))-low, uint64(int64(closestToZero(min, max)))-low
+		return T(int64(low + zigZag(r.draw(n), zero, n)))
+	default:
+		low := uint64(min)
+		return T(low + r.draw(uint64(max)-low))
+	}
 }
 
-func randUint32(n uint32) uint32 {
-	return uint32(random.Int31n(int32(n)))
+// zigZag maps the given choice within [0, n] to an offset within [0, n], such that 0 is mapped to the given zero offset,
+// and the following choices alternate above and below it (zero+1, zero-1, zero+2, ...) until one of the sides runs out.
+// It's a bijection, so the uniformly distributed choices are mapped to uniformly distributed offsets.
+func zigZag(choice, zero, n uint64) uint64 {
+	below, above := zero, n-zero
+	m := below
+	if above < m {
+		m = above
+	}
+	switch {
+	case choice <= 2*m && choice%2 == 1:
+		return zero + (choice+1)/2
+	case choice <= 2*m:
+		return zero - choice/2
+	case above > below:
+		return zero + (choice - m)
+	default:
+		return zero - (choice - m)
+	}
 }
 
-func randUint64(n uint64) uint64 {
-	return uint64(random.Int63n(int64(n)))
+// closestToZero returns the value within [min, max] which is the closest to zero.
+func closestToZero[T Numeric](min, max T) T {
+	switch {
+	case min > 0:
+		return min
+	case max < 0:
+		return max
+	default:
+		return 0
+	}
 }
 
-func randUint(n uint) uint {
-	if n <= 1<<32-1 {
-		return uint(randUint32(uint32(n)))
+// finiteFloat returns a finite float, which is drawn uniformly among the representations of finite floats.
+// The representations of non-negative floats are ordered just like their values, so simpler choices shrink
+// the value towards zero.
+func finiteFloat[T Numeric](r *Rand) T {
+	negative := r.draw(1) == 1
+	var value T
+	if _, ok := any(value).(float32); ok {
+		value = T(math.Float32frombits(uint32(r.draw(0x7f7fffff))))
 	} else {
-		return uint(randUint64(uint64(n)))
+		value = T(math.Float64frombits(r.draw(0x7fefffffffffffff)))
+	}
+	if negative {
+		return -value
+	}
+	return value
+}
+
+// uniformFloat returns a uniformly distributed float within [min, max] (inclusive).
+// The value is interpolated between min and max rather than offset from min, since max - min
+// overflows to infinity for ranges wider than the largest float.
+// The steps of the interpolation are zig-zagged around the one closest to zero, like the offsets of the integers.
+func uniformFloat[T Numeric](r *Rand, min, max T) T {
+	const steps = 1 << 53
+	var zero uint64
+	switch {
+	case max <= 0:
+		zero = steps
+	case min < 0:
+		// The fraction of the range below zero, which is computed without subtracting the bounds to avoid overflowing.
+		zero = uint64(math.Round(steps / (1 - float64(max)/float64(min))))
+	}
+	step := zigZag(r.draw(steps), zero, steps)
+	if step == zero {
+		return closestToZero(min, max)
+	}
+	f := float64(step) / steps
+	value := T(float64(min)*(1-f) + float64(max)*f)
+	// Rounding might push the value slightly out of the range.
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
 	}
+	return value
 }
 
-func randInt8(n int8) int8 {
-	return int8(random.Int31n(int32(n)))
+// NumericOption modifies the way numeric generators generate values.
+type NumericOption func(*numericConfig)
+
+type numericConfig struct {
+	edgeCaseRatio float64
 }
 
-func randInt16(n int16) int16 {
-	return int16(random.Int31n(int32(n)))
+func newNumericConfig(options []NumericOption) *numericConfig {
+	config := &numericConfig{}
+	for _, option := range options {
+		option(config)
+	}
+	return config
 }
 
-func randInt32(n int32) int32 {
-	return random.Int31n(n)
+// defaultEdgeCaseRatio is the ratio of the edge cases generated by Arbitrary.
+const defaultEdgeCaseRatio = 0.1
+
+// EdgeCases makes a numeric generator generate edge cases instead of uniform values, with the given probability
+// (within [0, 1]). Edge cases are the boundaries of the range, and the values around zero (0, 1, and -1) within it.
+// Floats also have the smallest (subnormal) values, the largest subnormal values and -0.0 as edge cases.
+func EdgeCases(ratio float64) NumericOption {
+	if ratio < 0 || ratio > 1 || ratio != ratio {
+		panic(fmt.Errorf("gen: edge case ratio must be within [0, 1], got: %v", ratio))
+	}
+	return func(c *numericConfig) { c.edgeCaseRatio = ratio }
 }
 
-func randInt64(n int64) int64 {
-	return random.Int63n(n)
+// numericBounds returns the minimum and the maximum finite values of T.
+func numericBounds[T Numeric]() (T, T) {
+	var min, max any
+	switch any(T(0)).(type) {
+	case uint8, uint16, uint32, uint64, uint:
+		zero := T(0)
+		return 0, zero - 1
+	case int8:
+		min, max = int8(math.MinInt8), int8(math.MaxInt8)
+	case int16:
+		min, max = int16(math.MinInt16), int16(math.MaxInt16)
+	case int32:
+		min, max = int32(math.MinInt32), int32(math.MaxInt32)
+	case int64:
+		min, max = int64(math.MinInt64), int64(math.MaxInt64)
+	case int:
+		min, max = math.MinInt, math.MaxInt
+	case float32:
+		min, max = float32(-math.MaxFloat32), float32(math.MaxFloat32)
+	case float64:
+		min, max = -math.MaxFloat64, math.MaxFloat64
+	}
+	return min.(T), max.(T)
 }
 
-func randInt(n int) int {
-	return random.Intn(n)
+// floatEdgeCases returns the smallest positive subnormal, the largest subnormal, and the smallest normal value of T.
+func floatEdgeCases[T Numeric]() []T {
+	if _, ok := any(T(0)).(float32); ok {
+		return []T{
+			T(math.Float32frombits(0x00000001)),
+			T(math.Float32frombits(0x007fffff)),
+			T(math.Float32frombits(0x00800000)),
+		}
+	}
+	return []T{
+		T(math.Float64frombits(0x0000000000000001)),
+		T(math.Float64frombits(0x000fffffffffffff)),
+		T(math.Float64frombits(0x0010000000000000)),
+	}
 }
 
-func randFloat32(n float32) float32 {
-	return random.Float32() * n
+// edgeCases returns the edge cases of the given range, the first of which is the value closest to zero,
+// so that the simplest edge case is chosen first while shrinking.
+func edgeCases[T Numeric](min, max T) []T {
+	var cases []T
+	add := func(values ...T) {
+		for _, value := range values {
+			if value >= min && value <= max && !containsNumeric(cases, value) {
+				cases = append(cases, value)
+			}
+		}
+	}
+
+	one := T(1)
+	add(closestToZero(min, max), 0, one, 0-one, min, max)
+	if !isFloat[T]() {
+		add(min+1, max-1)
+		return cases
+	}
+
+	add(T(math.Copysign(0, -1)))
+	for _, value := range floatEdgeCases[T]() {
+		add(value, -value)
+	}
+	return cases
 }
 
-func randFloat64(n float64) float64 {
-	return random.Float64() * n
+// specialFloats returns the special IEEE values of T.
+func specialFloats[T Numeric]() []T {
+	return []T{T(math.NaN()), T(math.Inf(1)), T(math.Inf(-1))}
+}
+
+// containsNumeric reports whether the given values contain the given value,
+// floats are compared by their bits, so that -0.0 is told apart from 0.
+func containsNumeric[T Numeric](values []T, value T) bool {
+	for _, v := range values {
+		if isFloat[T]() && math.Float64bits(float64(v)) == math.Float64bits(float64(value)) {
+			return true
+		}
+		if !isFloat[T]() && v == value {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,132 @@
+package gen
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBetweenWithEdgeCases(t *testing.T) {
+	g := Between(-100, 100, EdgeCases(1))
+
+	expected := map[int]bool{0: true, 1: true, -1: true, -100: true, 100: true, -99: true, 99: true}
+	for _, value := range GenerateN(g, 1000) {
+		if !expected[value] {
+			t.Fatalf("Between generated %d, which is not an edge case, with an edge case ratio of 1", value)
+		}
+	}
+}
+
+func TestBetweenEdgeCasesAreWithinTheRange(t *testing.T) {
+	g := Between(10.0, 20.0, EdgeCases(1))
+
+	for _, value := range GenerateN(g, 100) {
+		if value != 10 && value != 20 {
+			t.Fatalf("Between generated an edge case out of its range: %v", value)
+		}
+	}
+}
+
+func TestArbitraryGeneratesEdgeCases(t *testing.T) {
+	var sawMin, sawMax bool
+	for _, value := range GenerateN(Arbitrary[int64](), 1000) {
+		sawMin = sawMin || value == math.MinInt64
+		sawMax = sawMax || value == math.MaxInt64
+	}
+	if !sawMin || !sawMax {
+		t.Errorf("Arbitrary did not generate the bounds of int64")
+	}
+
+	var sawNaN, sawInf, sawNegativeZero, sawSubnormal bool
+	for _, value := range GenerateN(Arbitrary[float64](EdgeCases(1)), 1000) {
+		sawNaN = sawNaN || math.IsNaN(value)
+		sawInf = sawInf || math.IsInf(value, 0)
+		sawNegativeZero = sawNegativeZero || (value == 0 && math.Signbit(value))
+		sawSubnormal = sawSubnormal || (value != 0 && math.Abs(value) < 0x1p-1022)
+	}
+	if !sawNaN || !sawInf || !sawNegativeZero || !sawSubnormal {
+		t.Errorf("Arbitrary did not generate the special float values, NaN: %v, Inf: %v, -0.0: %v, subnormal: %v",
+			sawNaN, sawInf, sawNegativeZero, sawSubnormal)
+	}
+}
+
+func TestArbitraryWithoutEdgeCases(t *testing.T) {
+	for _, value := range GenerateN(Arbitrary[float32](EdgeCases(0)), 1000) {
+		if math.IsNaN(float64(value)) || math.IsInf(float64(value), 0) {
+			t.Fatalf("Arbitrary generated a special float value with an edge case ratio of 0: %v", value)
+		}
+	}
+}
+
+func TestEdgeCasesWithInvalidRatio(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("EdgeCases did not panic for a ratio greater than 1")
+		}
+	}()
+	EdgeCases(1.5)
+}
+
+func TestArbitraryShrinksToZero(t *testing.T) {
+	err := Check(Arbitrary[int](), func(n int) bool { return n < 1000 })
+
+	if actual := counterexampleOf(t, err); actual != 1000 {
+		t.Errorf("Arbitrary was not shrunk to the smallest counterexample, expected: 1000, got: %v", actual)
+	}
+}
+
+func TestArbitraryFloatsCoverEveryMagnitude(t *testing.T) {
+	var small, large bool
+	for _, value := range GenerateN(ArbitraryFloat64, 1000) {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			t.Fatalf("ArbitraryFloat64 generated a non-finite value: %v", value)
+		}
+		small = small || math.Abs(value) < 1e-100
+		large = large || math.Abs(value) > 1e100
+	}
+	if !small || !large {
+		t.Errorf("ArbitraryFloat64 did not cover small and large magnitudes, small: %v, large: %v", small, large)
+	}
+
+	for _, value := range GenerateN(ArbitraryFloat32, 1000) {
+		if math.IsNaN(float64(value)) || math.IsInf(float64(value), 0) {
+			t.Fatalf("ArbitraryFloat32 generated a non-finite value: %v", value)
+		}
+	}
+}
+
+func TestArbitrarySmallInts(t *testing.T) {
+	var sawNegative, sawLarge bool
+	for _, value := range GenerateN(ArbitraryInt16, 1000) {
+		sawNegative = sawNegative || value < 0
+		sawLarge = sawLarge || value > math.MaxInt8
+	}
+	if !sawNegative || !sawLarge {
+		t.Errorf("ArbitraryInt16 did not cover its domain")
+	}
+
+	type Small struct {
+		A int8
+		B int16
+	}
+	g, err := Infer[Small]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sawNegative = false
+	for _, value := range GenerateN(g, 100) {
+		sawNegative = sawNegative || value.A < 0
+	}
+	if !sawNegative {
+		t.Errorf("inferred int8 fields were not generated within the whole domain of int8")
+	}
+}
+
+func TestComplexGen(t *testing.T) {
+	g := Complex128Gen(Between(0.0, 1.0), Between(-1.0, 0.0))
+
+	for _, value := range GenerateN(g, 100) {
+		if real(value) < 0 || real(value) > 1 || imag(value) < -1 || imag(value) > 0 {
+			t.Fatalf("Complex128Gen did not respect the generators of its parts: %v", value)
+		}
+	}
+}
@@ -0,0 +1,370 @@
+package gen
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+	"time"
+)
+
+// TestingT is the subset of `*testing.T` (and `*testing.B`) that ForAll needs to report failures.
+type TestingT interface {
+	Helper()
+	Name() string
+	Logf(format string, args ...any)
+	Fatalf(format string, args ...any)
+}
+
+// Property describes a predicate over the generated values of type `T`.
+// It either reports whether the value satisfies the property as a bool, or describes why it doesn't as an error.
+type Property[T any] interface {
+	func(T) bool | func(T) error
+}
+
+var (
+	defaultIterations      = 100
+	defaultMaxDiscardRatio = 10
+)
+
+// Config holds the settings of a property check.
+type Config struct {
+	// Iterations is the number of values that are generated and checked against the property.
+	Iterations int
+	// Seed is used to seed the Rand which the values are generated from, zero means a seed based on the current time.
+	// Each check owns its Rand, so checks running in parallel do not affect each other.
+	// Generators which don't draw from the given Rand (like the ones created by Pure, or custom implementations of Gen)
+	// draw from the package-level random generator, which is re-seeded before each iteration only while no other check
+	// is running, so their values are only reproduced deterministically in every case if they're built using PureWith.
+	Seed int64
+	// MaxShrinks is the maximum number of times a counterexample is shrunk, zero disables shrinking.
+	MaxShrinks int
+	// MaxDiscardRatio is the number of iterations which can be discarded (because a filtered generator gave up)
+	// for each iteration of the check, before the check gives up.
+	MaxDiscardRatio int
+	// MaxSize is the size of the last iteration, the size of the iterations grows from zero up to MaxSize.
+	// See Sized for how the size affects the generated values.
+	MaxSize int
+	// Replay is a replay token printed by a failing check, it overrides the Seed, and if it contains an iteration,
+	// only that iteration is checked. The -gen.seed flag and the GEN_SEED environment variable override it,
+	// for the check named in their token (or for every check, if it names no test).
+	// If the replayed iteration is discarded (because a filtered generator gave up), the check returns an error.
+	Replay string
+	// CorpusDir is the directory where ForAll saves the failing counterexamples, under subdirectories named after the test
+	// and the type of the values, so that the properties of different types checked by the same test have their own entries.
+	// The saved counterexamples are checked before the generated values on every subsequent run of the test,
+	// as long as they can be encoded to (and decoded from) JSON. Empty disables the corpus.
+	CorpusDir string
+
+	// testName is the name of the test running the check, which the replay tokens are prefixed with,
+	// along with call, the number of the ForAll call running the check in that test.
+	testName string
+	call     int
+}
+
+// Option modifies the Config of a property check.
+type Option func(*Config)
+
+// Iterations sets the number of values that are generated and checked against the property.
+func Iterations(n int) Option {
+	return func(c *Config) { c.Iterations = n }
+}
+
+// WithSeed makes the property check use the given seed, so that a previous run can be reproduced.
+// The values of Pure and custom generators are only reproduced if no other checks run at the same time, see Config.Seed.
+func WithSeed(seed int64) Option {
+	return func(c *Config) { c.Seed = seed }
+}
+
+// MaxShrinks sets the maximum number of times a counterexample is shrunk, zero disables shrinking.
+func MaxShrinks(n int) Option {
+	return func(c *Config) { c.MaxShrinks = n }
+}
+
+// Replay makes the property check replay the given replay token, which is printed by a failing check.
+// The values of Pure and custom generators are only replayed if no other checks run at the same time, see Config.Seed.
+func Replay(token string) Option {
+	return func(c *Config) { c.Replay = token }
+}
+
+// MaxDiscardRatio sets the number of iterations which can be discarded for each iteration of the check, see Config.MaxDiscardRatio.
+func MaxDiscardRatio(n int) Option {
+	return func(c *Config) { c.MaxDiscardRatio = n }
+}
+
+// MaxSize sets the size of the last iteration of the check, see Config.MaxSize.
+func MaxSize(n int) Option {
+	return func(c *Config) { c.MaxSize = n }
+}
+
+// CorpusDir sets the directory of the corpus of failing counterexamples, empty disables the corpus.
+func CorpusDir(dir string) Option {
+	return func(c *Config) { c.CorpusDir = dir }
+}
+
+// newConfig creates the Config of a check run by the given ForAll call of the given test
+// (an empty name stands for the checks run by Check).
+func newConfig(options []Option, testName string, call int) *Config {
+	config := &Config{
+		Iterations: defaultIterations, MaxShrinks: defaultMaxShrinks, MaxDiscardRatio: defaultMaxDiscardRatio,
+		MaxSize: defaultSize, CorpusDir: defaultCorpusDir,
+	}
+	for _, option := range options {
+		option(config)
+	}
+	if config.Iterations <= 0 {
+		config.Iterations = defaultIterations
+	}
+	if config.MaxDiscardRatio < 0 {
+		config.MaxDiscardRatio = 0
+	}
+	if config.MaxSize < 0 {
+		config.MaxSize = 0
+	}
+	if config.Seed == 0 {
+		config.Seed = time.Now().UTC().UnixNano()
+	}
+	if token := replayToken(testName, call); token != "" {
+		config.Replay = token
+	}
+	config.testName, config.call = testName, call
+	return config
+}
+
+// CheckError is returned by Check when a generated value does not satisfy the property.
+type CheckError struct {
+	// Seed is the seed that the check was run with, passing it back using WithSeed reproduces the failure.
+	Seed int64
+	// Token is the replay token which reproduces the failing iteration, see Replay.
+	// It's empty if the counterexample was loaded from the corpus.
+	Token string
+	// Iteration is the (zero-based) index of the generated value which failed the property.
+	Iteration int
+	// Size is the size which the failing value was generated with.
+	Size int
+	// CorpusFile is the corpus file which the counterexample was loaded from, or saved into.
+	CorpusFile string
+	// Counterexample is the (shrunk) value which failed the property.
+	Counterexample any
+	// Original is the value which originally failed the property, before being shrunk.
+	Original any
+	// Shrinks is the number of times the original value was shrunk to get to the counterexample.
+	Shrinks int
+	// Err describes why the counterexample failed the property.
+	Err error
+}
+
+func (e *CheckError) Error() string {
+	counterexample := fmt.Sprintf("%+v", e.Counterexample)
+	if e.Shrinks > 0 {
+		counterexample += fmt.Sprintf(" (shrunk %d times from %+v)", e.Shrinks, e.Original)
+	}
+	if e.Token == "" {
+		return fmt.Sprintf(
+			"property failed on corpus entry %s\ncounterexample: %s\nreason: %v", e.CorpusFile, counterexample, e.Err,
+		)
+	}
+	message := fmt.Sprintf(
+		"property failed after %d tests (seed: %d, size: %d)\ncounterexample: %s\nreason: %v\nreplay with: -gen.seed=%s (or %s=%s)",
+		e.Iteration+1, e.Seed, e.Size, counterexample, e.Err, e.Token, replayEnv, e.Token,
+	)
+	if e.CorpusFile != "" {
+		message += fmt.Sprintf("\ncounterexample saved to: %s", e.CorpusFile)
+	}
+	return message
+}
+
+func (e *CheckError) Unwrap() error { return e.Err }
+
+// GaveUpError is returned by Check when too many iterations are discarded, because a filtered generator gave up.
+type GaveUpError struct {
+	// Passed is the number of iterations which satisfied the property before the check gave up.
+	Passed int
+	// Discarded is the number of discarded iterations.
+	Discarded int
+	// Err is the error of the last filtered generator that gave up.
+	Err *FilterError
+}
+
+func (e *GaveUpError) Error() string {
+	return fmt.Sprintf("gave up after %d passed and %d discarded iterations: %v", e.Passed, e.Discarded, e.Err)
+}
+
+func (e *GaveUpError) Unwrap() error { return e.Err }
+
+// runningChecks is the number of checks which are running at the moment.
+var runningChecks int32
+
+var errPropertyFalsified = errors.New("property returned false")
+
+// evaluate runs the property against the given value, recovering from panics and turning them into errors.
+func evaluate[T any, P Property[T]](property P, value T) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("property panicked: %v", r)
+		}
+	}()
+
+	switch p := any(property).(type) {
+	case func(T) bool:
+		if !p(value) {
+			return errPropertyFalsified
+		}
+		return nil
+	case func(T) error:
+		return p(value)
+	default:
+		panic(fmt.Errorf("match error: unrecognized Property type %T", property))
+	}
+}
+
+// shrinkCounterexample shrinks the failing value using the generator as a Shrinker, if it is one.
+func shrinkCounterexample[T any, P Property[T]](g Gen[T], property P, checkErr *CheckError, value T, err error, maxShrinks int) {
+	if shrinker, ok := shrinkerOf(g); ok {
+		var shrinks int
+		value, err, shrinks = shrink(shrinker, property, value, err, maxShrinks-checkErr.Shrinks)
+		checkErr.Shrinks += shrinks
+	}
+	checkErr.Counterexample, checkErr.Err = value, err
+}
+
+// Check generates values using the given generator, and checks them against the property.
+// It returns a *CheckError describing the first value that does not satisfy the property, or nil if all of them do.
+// Iterations in which a filtered generator gives up are discarded, and a *GaveUpError is returned if too many of them are.
+// When a unique generator runs out of new values, the check stops and returns its *ExhaustedError (wrapped).
+// The failing value is shrunk by simplifying the random choices it was generated from, and then using the generator
+// as a Shrinker if it is one, to find the smallest value that still fails the property.
+func Check[T any, P Property[T]](g Gen[T], property P, options ...Option) error {
+	_, err := check[T](g, property, newConfig(options, "", 0))
+	return err
+}
+
+// iterationSize returns the size of the given iteration, which grows linearly from zero up to MaxSize.
+func (c *Config) iterationSize(iteration int) int {
+	if c.Iterations <= 1 || iteration >= c.Iterations-1 {
+		return c.MaxSize
+	}
+	return iteration * c.MaxSize / (c.Iterations - 1)
+}
+
+// check runs the check, and returns the number of discarded iterations along with the result.
+func check[T any, P Property[T]](g Gen[T], property P, config *Config) (int, error) {
+	first, iterations, replaySize, replayingIteration := 0, config.Iterations, -1, false
+	if config.Replay != "" {
+		seed, iteration, size, err := parseReplayToken(config.Replay)
+		if err != nil {
+			return 0, err
+		}
+		config.Seed, replaySize = seed, size
+		if iteration >= 0 {
+			first, iterations, replayingIteration = iteration, 1, true
+		}
+	}
+	src := rand.NewSource(config.Seed)
+	r := recordingRand(rand.New(src))
+	passed, discarded := 0, 0
+	atomic.AddInt32(&runningChecks, 1)
+	defer atomic.AddInt32(&runningChecks, -1)
+
+	for i := first; passed < iterations; i++ {
+		src.Seed(iterationSeed(config.Seed, i))
+		if atomic.LoadInt32(&runningChecks) == 1 {
+			// The generators which don't draw from r draw from the package-level random generator, which is shared.
+			Seed(iterationSeed(config.Seed, i))
+		}
+		r.reset()
+		r.size = config.iterationSize(i)
+		if replaySize >= 0 {
+			r.size = replaySize
+		}
+		value, genErr := tryGenerate(g, r)
+		if exhaustedErr, ok := genErr.(*ExhaustedError); ok {
+			// Discarding the iteration would not help, the generator keeps giving up from then on.
+			return discarded, fmt.Errorf("iteration %d could not be generated: %w", i, exhaustedErr)
+		}
+		if filterErr, ok := genErr.(*FilterError); ok {
+			if replayingIteration {
+				// Moving on to the next iterations would check values which have nothing to do with the replayed one.
+				return 1, fmt.Errorf("replay token %s did not reproduce the iteration, it was discarded: %w", config.Replay, filterErr)
+			}
+			if discarded++; discarded > config.MaxDiscardRatio*iterations {
+				return discarded, &GaveUpError{passed, discarded, filterErr}
+			}
+			continue
+		}
+		if err := evaluate[T](property, value); err != nil {
+			checkErr := &CheckError{
+				Seed: config.Seed, Token: formatReplayToken(config.testName, config.call, config.Seed, i, r.size), Iteration: i, Size: r.size, Original: value,
+			}
+			choices := append([]uint64{}, r.choices...)
+			value, err, checkErr.Shrinks = shrinkChoices(g, property, choices, r.size, value, err, config.MaxShrinks)
+			shrinkCounterexample(g, property, checkErr, value, err, config.MaxShrinks)
+			return discarded, checkErr
+		}
+		passed++
+	}
+	return discarded, nil
+}
+
+// checkCorpus checks the counterexamples saved in the corpus of the test against the property.
+func checkCorpus[T any, P Property[T]](t TestingT, g Gen[T], property P, config *Config, dir string) error {
+	t.Helper()
+	entries, skipped, err := loadCorpus[T](dir)
+	if err != nil {
+		return fmt.Errorf("could not load the corpus: %w", err)
+	}
+	for file, err := range skipped {
+		t.Logf("skipping corpus entry %s: %v", file, err)
+	}
+	for _, entry := range entries {
+		if err := evaluate[T](property, entry.value); err != nil {
+			checkErr := &CheckError{Seed: config.Seed, CorpusFile: entry.file, Original: entry.value}
+			shrinkCounterexample(g, property, checkErr, entry.value, err, config.MaxShrinks)
+			return checkErr
+		}
+	}
+	return nil
+}
+
+// ForAll checks that all the values generated by the given generator satisfy the property,
+// and fails the test with the counterexample and the replay token to reproduce it otherwise.
+// Passing the replay token back using the -gen.seed test flag (or the GEN_SEED environment variable) reruns the failing case,
+// the token is prefixed with the name of the test and the number of the ForAll call in it, so that the other checks
+// run as usual (and check their corpus):
+//
+//	go test -run TestName -gen.seed=TestName#2@1697040000:17:8
+//
+// The failing counterexamples are also saved in the corpus of the test (testdata/gen/<TestName>/<Type> by default),
+// and they are checked first on every subsequent run, see Config.CorpusDir.
+func ForAll[T any, P Property[T]](t TestingT, g Gen[T], property P, options ...Option) {
+	t.Helper()
+	config := newConfig(options, t.Name(), nextForAllCall(t))
+	dir := ""
+	if config.Replay == "" {
+		dir = testCorpusDir[T](config, t.Name())
+	}
+
+	if dir != "" {
+		if err := checkCorpus(t, g, property, config, dir); err != nil {
+			t.Fatalf("%v", err)
+			return
+		}
+	}
+
+	discarded, err := check[T](g, property, config)
+	if discarded > 0 && err == nil {
+		t.Logf("%d iterations were discarded (%.1f%% of the generated values)",
+			discarded, 100*float64(discarded)/float64(discarded+config.Iterations))
+	}
+	var checkErr *CheckError
+	if errors.As(err, &checkErr) && dir != "" {
+		if file, saveErr := saveCorpusEntry(dir, checkErr.Counterexample); saveErr != nil {
+			t.Logf("could not save the counterexample to the corpus: %v", saveErr)
+		} else {
+			checkErr.CorpusFile = file
+		}
+	}
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+}
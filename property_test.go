@@ -0,0 +1,84 @@
+package gen
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type fakeT struct {
+	name    string
+	failed  bool
+	message string
+	logs    []string
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Name() string { return f.name }
+
+func (f *fakeT) Logf(format string, args ...any) {
+	f.logs = append(f.logs, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeT) Fatalf(format string, args ...any) {
+	f.failed = true
+	f.message = fmt.Sprintf(format, args...)
+}
+
+func TestForAllWithSatisfiedProperty(t *testing.T) {
+	calls := 0
+	ForAll(t, Between(1, 100), func(n int) bool {
+		calls++
+		return isBetween(n, 1, 100)
+	}, Iterations(42))
+
+	if calls != 42 {
+		t.Errorf("ForAll did not run the configured number of iterations, expected: 42, got: %d", calls)
+	}
+}
+
+func TestForAllReportsCounterexampleAndSeed(t *testing.T) {
+	ft := &fakeT{}
+	ForAll(ft, Between(1, 100), func(n int) bool { return n < 50 }, WithSeed(42))
+
+	if !ft.failed {
+		t.Fatal("ForAll did not fail for a property that does not hold")
+	}
+	if !strings.Contains(ft.message, "seed: 42") || !strings.Contains(ft.message, "counterexample") {
+		t.Errorf("ForAll failure message does not contain the seed or the counterexample: %s", ft.message)
+	}
+}
+
+func TestCheckIsReproducibleWithSeed(t *testing.T) {
+	property := func(n int) error {
+		if n%7 == 0 {
+			return fmt.Errorf("%d is divisible by 7", n)
+		}
+		return nil
+	}
+
+	err1 := Check(ArbitraryInt, property, WithSeed(1234))
+	err2 := Check(ArbitraryInt, property, WithSeed(1234))
+
+	var ce1, ce2 *CheckError
+	if !errors.As(err1, &ce1) || !errors.As(err2, &ce2) {
+		t.Fatalf("Check did not return CheckErrors, got: %v and %v", err1, err2)
+	}
+	if ce1.Counterexample != ce2.Counterexample || ce1.Iteration != ce2.Iteration {
+		t.Errorf("Check with the same seed found different counterexamples: %v and %v", ce1.Counterexample, ce2.Counterexample)
+	}
+}
+
+func TestCheckRecoversFromPanics(t *testing.T) {
+	err := Check(Only(0), func(n int) bool { return 10/n > 0 })
+
+	var ce *CheckError
+	if !errors.As(err, &ce) {
+		t.Fatalf("Check did not turn the panic into a CheckError, got: %v", err)
+	}
+	if !strings.Contains(ce.Err.Error(), "panicked") {
+		t.Errorf("unexpected failure reason: %v", ce.Err)
+	}
+}
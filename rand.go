@@ -0,0 +1,206 @@
+package gen
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Rand is the source of randomness which generators draw their random choices from.
+// Generators describe the values they generate in terms of these choices, so recording the choices
+// and replaying a simplified sequence of them shrinks the value, no matter how the generator was composed.
+// A Rand is deterministic given its seed, and it's not safe for concurrent use, so each test should own its own Rand.
+type Rand struct {
+	rnd  *rand.Rand
+	size int
+
+	// replay holds the choices to be replayed, instead of drawing them from rnd.
+	replay    []uint64
+	replaying bool
+	pos       int
+
+	// choices holds the recorded choices, if record is set.
+	record  bool
+	choices []uint64
+}
+
+// defaultSize is the size of the Rands which are not given any, generators reach their full range at this size.
+const defaultSize = 50
+
+// NewRand creates a new Rand, seeded with the given seed.
+func NewRand(seed int64) *Rand {
+	return newRand(rand.New(rand.NewSource(seed)))
+}
+
+func newRand(rnd *rand.Rand) *Rand {
+	return &Rand{rnd: rnd, size: defaultSize}
+}
+
+// recordingRand records the choices drawn from rnd.
+func recordingRand(rnd *rand.Rand) *Rand {
+	return &Rand{rnd: rnd, size: defaultSize, record: true}
+}
+
+// replayingRand replays the given choices with the given size, and records the ones actually used.
+// Once the choices are exhausted, it keeps on choosing zero, which is the simplest choice.
+func replayingRand(choices []uint64, size int) *Rand {
+	return &Rand{size: size, replay: choices, replaying: true, record: true}
+}
+
+// Size returns the size which the generators drawing from this Rand should respect.
+// Collections and strings are generated with up to (roughly) size elements, so values start small and grow with the size.
+func (r *Rand) Size() int {
+	return r.size
+}
+
+// withSize generates a value using the given generator, with the size of the Rand temporarily set to the given size.
+// The Rand is copied rather than modified, since the default Rand is shared among goroutines, and the choices are
+// only carried back if it's recording or replaying them, which the shared ones never do.
+func withSize[T any](r *Rand, size int, g Gen[T]) T {
+	resized := *r
+	resized.size = size
+	value := GenerateWith(g, &resized)
+	if r.record || r.replaying {
+		r.pos, r.choices = resized.pos, resized.choices
+	}
+	return value
+}
+
+// scale returns the upper bound of a range within [min, max], which grows with the size,
+// and covers the whole range once the size reaches defaultSize.
+func (r *Rand) scale(min, max int) int {
+	if r.size >= defaultSize || max <= min {
+		return max
+	}
+	if r.size <= 0 {
+		return min
+	}
+	return min + int(float64(max-min)*float64(r.size)/defaultSize)
+}
+
+// reset clears the recorded choices.
+func (r *Rand) reset() {
+	r.choices = r.choices[:0]
+	r.pos = 0
+}
+
+// draw makes a choice within [0, n] (inclusive).
+func (r *Rand) draw(n uint64) uint64 {
+	var choice uint64
+	if r.replaying {
+		if r.pos < len(r.replay) {
+			choice = r.replay[r.pos]
+		}
+		r.pos++
+		if choice > n {
+			choice = n
+		}
+	} else {
+		choice = uniformUint64(r.rnd, n)
+	}
+	if r.record {
+		r.choices = append(r.choices, choice)
+	}
+	return choice
+}
+
+// below makes a choice within [0, n), it always chooses 0 if n is 0.
+func (r *Rand) below(n uint64) uint64 {
+	if n == 0 {
+		return 0
+	}
+	return r.draw(n - 1)
+}
+
+// intn makes a choice within [0, n), it always chooses 0 if n is not positive.
+func (r *Rand) intn(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	return int(r.below(uint64(n)))
+}
+
+// float64 makes a choice within [0, 1).
+func (r *Rand) float64() float64 {
+	return float64(r.draw(1<<53-1)) / (1 << 53)
+}
+
+// Uint64n returns a random value within [0, n), it panics if n is 0.
+func (r *Rand) Uint64n(n uint64) uint64 {
+	if n == 0 {
+		panic("gen: invalid argument to Uint64n")
+	}
+	return r.below(n)
+}
+
+// Intn returns a random value within [0, n), it panics if n is not positive.
+func (r *Rand) Intn(n int) int {
+	if n <= 0 {
+		panic("gen: invalid argument to Intn")
+	}
+	return r.intn(n)
+}
+
+// Float64 returns a random value within [0, 1).
+func (r *Rand) Float64() float64 {
+	return r.float64()
+}
+
+// Bool returns a random boolean.
+func (r *Rand) Bool() bool {
+	return r.draw(1) == 1
+}
+
+// uniformUint64 returns a uniformly distributed value within [0, n] (inclusive).
+func uniformUint64(rnd *rand.Rand, n uint64) uint64 {
+	if n == math.MaxUint64 {
+		return rnd.Uint64()
+	}
+	bound := n + 1
+	// Values below threshold are rejected, so that the remaining ones are a multiple of bound.
+	threshold := -bound % bound
+	for {
+		if v := rnd.Uint64(); v >= threshold {
+			return v % bound
+		}
+	}
+}
+
+// randGen is implemented by generators which draw their random choices from a Rand.
+type randGen[T any] interface {
+	generate(r *Rand) T
+}
+
+// GenerateWith generates a value using the given generator, drawing the random choices from the given Rand.
+// Generators which do not draw their choices from a Rand (e.g., the ones created by Pure) use the default one.
+func GenerateWith[T any](g Gen[T], r *Rand) T {
+	if rg, ok := g.(randGen[T]); ok {
+		return rg.generate(r)
+	}
+	return g.Generate()
+}
+
+// GenerateNWith generates n values using the given generator, drawing the random choices from the given Rand.
+func GenerateNWith[T any](g Gen[T], r *Rand, n uint) []T {
+	if g == nil {
+		return nil
+	}
+	res := make([]T, n)
+	for i := uint(0); i < n; i++ {
+		res[i] = GenerateWith(g, r)
+	}
+	return res
+}
+
+type randPure[T any] struct {
+	generator func(r *Rand) T
+}
+
+func (p randPure[T]) Generate() T { return p.generator(defaultRand) }
+
+func (p randPure[T]) generate(r *Rand) T { return p.generator(r) }
+
+// PureWith is like Pure, but the generate function draws its random choices from the given Rand,
+// so the generator can be used with GenerateWith, and its values can be shrunk by the property runner.
+func PureWith[T any](generator func(r *Rand) T) Gen[T] {
+	return randPure[T]{generator}
+}
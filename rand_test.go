@@ -0,0 +1,69 @@
+package gen
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGenerateWithIsDeterministic(t *testing.T) {
+	g := Map3(
+		StringGen("abcdef", 0, 10), Between(0, 100), OneOf("a", "b", "c"),
+		func(s string, n int, o string) TestPerson { return TestPerson{s, o, n} },
+	)
+
+	first := GenerateNWith(g, NewRand(42), 100)
+	second := GenerateNWith(g, NewRand(42), 100)
+
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("generators did not generate the same values using Rands with the same seed")
+	}
+}
+
+func TestPureWithDrawsFromTheGivenRand(t *testing.T) {
+	g := PureWith(func(r *Rand) []int {
+		n := r.Intn(10)
+		values := make([]int, n)
+		for i := range values {
+			values[i] = int(r.Uint64n(1000))
+		}
+		return values
+	})
+
+	if !reflect.DeepEqual(GenerateWith(g, NewRand(7)), GenerateWith(g, NewRand(7))) {
+		t.Errorf("PureWith did not generate the same values using Rands with the same seed")
+	}
+}
+
+func TestParallelChecksAreReproducible(t *testing.T) {
+	g := Map2(ArbitraryInt, StringGen("xyz", 0, 20), func(n int, s string) TestPerson {
+		return TestPerson{Name: s, Age: n}
+	})
+	property := func(p TestPerson) bool { return p.Age%13 != 0 || len(p.Name) < 10 }
+
+	results := make([]any, 8)
+	for i := range results {
+		i := i
+		t.Run("parallel", func(t *testing.T) {
+			t.Parallel()
+			results[i] = Check(g, property, WithSeed(99), Iterations(1000))
+		})
+	}
+	t.Cleanup(func() {
+		for _, result := range results[1:] {
+			if !reflect.DeepEqual(result, results[0]) {
+				t.Errorf("parallel checks with the same seed had different results: %v and %v", results[0], result)
+			}
+		}
+	})
+}
+
+func TestChecksOfPureGeneratorsAreReproducible(t *testing.T) {
+	g := Pure(func() int { return Between(0, 1_000_000).Generate() })
+	property := func(n int) bool { return n%97 != 0 }
+
+	first := Check(g, property, WithSeed(5), Iterations(1000), MaxShrinks(0))
+	second := Check(g, property, WithSeed(5), Iterations(1000), MaxShrinks(0))
+	if first == nil || !reflect.DeepEqual(first, second) {
+		t.Errorf("checks of a Pure generator with the same seed had different results: %v and %v", first, second)
+	}
+}
@@ -0,0 +1,237 @@
+package gen
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// maxRegexRepeat is the maximum number of times which the unbounded repetitions (*, + and {n,}) are repeated
+// beyond their minimum.
+const maxRegexRepeat = 10
+
+// runeClass is a set of runes, represented as pairs of inclusive ranges, like the ones of regexp/syntax.
+type runeClass struct {
+	ranges []rune
+	// ends holds the number of runes up to the end of each range, to pick runes by binary search.
+	ends  []uint64
+	total uint64
+}
+
+func newRuneClass(ranges []rune) *runeClass {
+	c := &runeClass{}
+	for i := 0; i+1 < len(ranges); i += 2 {
+		c.add(ranges[i], ranges[i+1])
+	}
+	return c
+}
+
+// add adds the given range to the class, leaving out the surrogates, which cannot be encoded in valid UTF-8.
+func (c *runeClass) add(lo, hi rune) {
+	const surrogateMin, surrogateMax = 0xd800, 0xdfff
+	if lo <= surrogateMax && hi >= surrogateMin {
+		if lo < surrogateMin {
+			c.add(lo, surrogateMin-1)
+		}
+		if hi > surrogateMax {
+			c.add(surrogateMax+1, hi)
+		}
+		return
+	}
+	c.ranges = append(c.ranges, lo, hi)
+	c.total += uint64(hi-lo) + 1
+	c.ends = append(c.ends, c.total)
+}
+
+// pick chooses a rune of the class uniformly, the simplest choice is the first rune of the first range.
+func (c *runeClass) pick(r *Rand) rune {
+	n := r.draw(c.total - 1)
+	i := sort.Search(len(c.ends), func(i int) bool { return c.ends[i] > n })
+	start := c.ends[i] - (uint64(c.ranges[2*i+1]-c.ranges[2*i]) + 1)
+	return c.ranges[2*i] + rune(n-start)
+}
+
+var (
+	anyRune      = newRuneClass([]rune{0, unicode.MaxRune})
+	anyRuneNotNL = newRuneClass([]rune{0, '\n' - 1, '\n' + 1, unicode.MaxRune})
+)
+
+type regexGen struct {
+	re      *syntax.Regexp
+	classes map[*syntax.Regexp]*runeClass
+}
+
+func (g *regexGen) Generate() string { return g.generate(defaultRand) }
+
+func (g *regexGen) generate(r *Rand) string {
+	var b strings.Builder
+	g.write(r, g.re, &b)
+	return b.String()
+}
+
+func (g *regexGen) write(r *Rand, re *syntax.Regexp, b *strings.Builder) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		for _, c := range re.Rune {
+			if re.Flags&syntax.FoldCase != 0 {
+				c = foldCase(r, c)
+			}
+			b.WriteRune(c)
+		}
+	case syntax.OpCharClass:
+		b.WriteRune(g.classes[re].pick(r))
+	case syntax.OpAnyCharNotNL:
+		b.WriteRune(anyRuneNotNL.pick(r))
+	case syntax.OpAnyChar:
+		b.WriteRune(anyRune.pick(r))
+	case syntax.OpCapture:
+		g.write(r, re.Sub[0], b)
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		min, max, unbounded := repeatBounds(re)
+		if unbounded {
+			max = r.scale(min, max)
+		}
+		n := min + int(r.draw(uint64(max-min)))
+		for i := 0; i < n; i++ {
+			g.write(r, re.Sub[0], b)
+		}
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			g.write(r, sub, b)
+		}
+	case syntax.OpAlternate:
+		g.write(r, re.Sub[r.intn(len(re.Sub))], b)
+	}
+	// The empty matches generate nothing, and the anchors are trimmed from the ends of the regex before compiling it.
+}
+
+// repeatBounds returns the minimum and the maximum number of repetitions of the given repeat operator,
+// the unbounded ones are capped at maxRegexRepeat repetitions beyond their minimum.
+func repeatBounds(re *syntax.Regexp) (min, max int, unbounded bool) {
+	switch re.Op {
+	case syntax.OpStar:
+		return 0, maxRegexRepeat, true
+	case syntax.OpPlus:
+		return 1, 1 + maxRegexRepeat, true
+	case syntax.OpQuest:
+		return 0, 1, false
+	default:
+		if re.Max < 0 {
+			return re.Min, re.Min + maxRegexRepeat, true
+		}
+		return re.Min, re.Max, false
+	}
+}
+
+// foldCase chooses one of the case variants of the given rune, the simplest choice is the rune itself.
+func foldCase(r *Rand, c rune) rune {
+	variants := []rune{c}
+	for f := unicode.SimpleFold(c); f != c; f = unicode.SimpleFold(f) {
+		variants = append(variants, f)
+	}
+	return variants[r.intn(len(variants))]
+}
+
+// compile checks that strings can be generated for the given regexp, and prepares its character classes.
+func (g *regexGen) compile(re *syntax.Regexp) error {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return fmt.Errorf("gen: the regex does not match any strings")
+	case syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return fmt.Errorf("gen: word boundaries are not supported in regexes: %s", re)
+	case syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText:
+		return fmt.Errorf("gen: anchors are only supported at the beginning and the end of regexes: %s", re)
+	case syntax.OpCharClass:
+		class := newRuneClass(re.Rune)
+		if class.total == 0 {
+			return fmt.Errorf("gen: the character class does not match any runes: %s", re)
+		}
+		g.classes[re] = class
+	}
+	for _, sub := range re.Sub {
+		if err := g.compile(sub); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Regex creates a generator of strings which match the whole given regex (in the syntax of the regexp package).
+// Unbounded repetitions (*, + and {n,}) are repeated up to 10 times beyond their minimum, growing with the size.
+// Constructs which cannot be generated, such as word boundaries and anchors in the middle of the regex, are reported
+// as errors, as well as the ones which the regexp package does not support, such as backreferences:
+//
+//	zipCodeGen, err := gen.Regex(`[0-9]{5}(-[0-9]{4})?`)
+func Regex(pattern string) (Gen[string], error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil, fmt.Errorf("gen: invalid regex: %w", err)
+	}
+	// The regex is not simplified, since simplifying expands the counted repetitions into nested optional ones,
+	// which would make the longer repetitions exponentially unlikely.
+	g := &regexGen{re: trimAnchors(re), classes: make(map[*syntax.Regexp]*runeClass)}
+	if err := g.compile(g.re); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// trimAnchors removes the anchors from the beginning and the end of the given regex, which the generated strings
+// always satisfy, since they match the whole regex. Captures and the branches of alternations are trimmed as well,
+// as long as they're at the beginning or the end of the regex themselves.
+func trimAnchors(re *syntax.Regexp) *syntax.Regexp {
+	return trimAnchorsAt(re, true, true)
+}
+
+// trimAnchorsAt removes the anchors from the beginning of the given regex if it's at the beginning of the whole regex,
+// and from its end if it's at the end of the whole regex.
+func trimAnchorsAt(re *syntax.Regexp, beginning, end bool) *syntax.Regexp {
+	switch re.Op {
+	case syntax.OpBeginLine, syntax.OpBeginText:
+		if beginning {
+			return &syntax.Regexp{Op: syntax.OpEmptyMatch}
+		}
+	case syntax.OpEndLine, syntax.OpEndText:
+		if end {
+			return &syntax.Regexp{Op: syntax.OpEmptyMatch}
+		}
+	case syntax.OpCapture:
+		trimmed := *re
+		trimmed.Sub = []*syntax.Regexp{trimAnchorsAt(re.Sub[0], beginning, end)}
+		return &trimmed
+	case syntax.OpAlternate:
+		trimmed := *re
+		trimmed.Sub = make([]*syntax.Regexp, len(re.Sub))
+		for i, sub := range re.Sub {
+			trimmed.Sub[i] = trimAnchorsAt(sub, beginning, end)
+		}
+		return &trimmed
+	case syntax.OpConcat:
+		subs := append([]*syntax.Regexp{}, re.Sub...)
+		for i := 0; i < len(subs) && beginning; i++ {
+			if subs[i] = trimAnchorsAt(subs[i], true, end && i == len(subs)-1); subs[i].Op != syntax.OpEmptyMatch {
+				break
+			}
+		}
+		for i := len(subs) - 1; i >= 0 && end; i-- {
+			if subs[i] = trimAnchorsAt(subs[i], beginning && i == 0, true); subs[i].Op != syntax.OpEmptyMatch {
+				break
+			}
+		}
+		trimmed := *re
+		trimmed.Sub = subs
+		return &trimmed
+	}
+	return re
+}
+
+// MustRegex is like Regex, but panics if the regex cannot be generated.
+func MustRegex(pattern string) Gen[string] {
+	g, err := Regex(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
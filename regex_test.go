@@ -0,0 +1,77 @@
+package gen
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestRegexGeneratesMatchingStrings(t *testing.T) {
+	patterns := []string{
+		`[0-9]{5}(-[0-9]{4})?`,
+		`[a-z]+@[a-z]+\.(com|org|net)`,
+		`^\d{3}-\d{2}-\d{4}$`,
+		`(?i)hello world`,
+		`[^a-z]*`,
+		`\p{Greek}+\s\P{L}?`,
+		`a{2,}b{0,3}c*`,
+		`.+`,
+		`(?s).+`,
+		`(foo|bar|baz)*|x`,
+		`^$`,
+		`(?m)^a+$`,
+		`\A(x|y)\z`,
+		`^a$|^b$`,
+		`(^abc$)`,
+		`[\x{1F600}-\x{1F64F}]{1,3}`,
+		``,
+	}
+
+	for _, pattern := range patterns {
+		g, err := Regex(pattern)
+		if err != nil {
+			t.Fatalf("Regex failed for %q: %v", pattern, err)
+		}
+		re := regexp.MustCompile(`^(?:` + pattern + `)$`)
+		for _, value := range GenerateN(g, 200) {
+			if !utf8.ValidString(value) || !re.MatchString(value) {
+				t.Fatalf("Regex generated %q, which does not match %q", value, pattern)
+			}
+		}
+	}
+}
+
+func TestRegexCapsUnboundedRepetitions(t *testing.T) {
+	g := MustRegex(`a*b+`)
+
+	for _, value := range GenerateN(g, 200) {
+		if strings.Count(value, "a") > maxRegexRepeat || strings.Count(value, "b") > maxRegexRepeat+1 {
+			t.Fatalf("Regex did not cap the unbounded repetitions: %q", value)
+		}
+	}
+}
+
+func TestRegexWithUnsupportedConstructs(t *testing.T) {
+	for _, pattern := range []string{`(a)\1`, `\bword\b`, `[a`, `[^\x00-\x{10FFFF}]`, `a$b`, `a(^b)`, `(a$)b`, `^a|b^c`, `(?m)a$\n^b`} {
+		if _, err := Regex(pattern); err == nil {
+			t.Errorf("Regex did not return an error for %q", pattern)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustRegex did not panic for an invalid regex")
+		}
+	}()
+	MustRegex(`(a)\1`)
+}
+
+func TestRegexShrinking(t *testing.T) {
+	g := MustRegex(`[a-z]{1,20}`)
+	err := Check(g, func(s string) bool { return len(s) < 5 })
+
+	if actual := counterexampleOf(t, err); actual != "aaaaa" {
+		t.Errorf("Regex was not shrunk to the smallest counterexample, expected: aaaaa, got: %v", actual)
+	}
+}
@@ -0,0 +1,117 @@
+package gen
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// replayEnv is the environment variable which a replay token can be passed through, instead of the flag.
+const replayEnv = "GEN_SEED"
+
+var replayFlag = flag.String(
+	"gen.seed", "",
+	"replay token (`seed`, seed:iteration or seed:iteration:size) printed by a failing property check, to reproduce it; "+
+		"the tokens printed by ForAll are prefixed with the name of the test and the number of the ForAll call in it "+
+		"(Test#call@seed:iteration:size), and only replay that check, while the ones without a test name replay every check; "+
+		"it can also be passed through the "+replayEnv+" environment variable",
+)
+
+// forAllCalls holds the number of ForAll calls made by each test so far, so that the checks of a test can be told apart.
+var forAllCalls sync.Map
+
+// nextForAllCall returns the number of the ForAll call being made by the given test, starting from 1.
+func nextForAllCall(t TestingT) int {
+	calls, _ := forAllCalls.LoadOrStore(t, new(int32))
+	return int(atomic.AddInt32(calls.(*int32), 1))
+}
+
+// replayToken returns the replay token passed through the -gen.seed flag or the GEN_SEED environment variable,
+// if any, and if it's meant for the given check of the given test (an empty name stands for the checks run by Check).
+// Tokens without a test name are meant for every check, and the ones without a call number for every check of their test.
+func replayToken(testName string, call int) string {
+	token := *replayFlag
+	if token == "" {
+		token = os.Getenv(replayEnv)
+	}
+	tokenTest, _ := splitReplayToken(token)
+	if tokenTest == "" {
+		return token
+	}
+	tokenTest, tokenCall := splitReplayScope(tokenTest)
+	if tokenTest != testName || (tokenCall > 0 && tokenCall != call) {
+		return ""
+	}
+	return token
+}
+
+// splitReplayToken splits the given replay token into the scope it was printed in (the name of the test and
+// the number of the ForAll call, if any) and the rest of it.
+func splitReplayToken(token string) (scope, rest string) {
+	token = strings.TrimSpace(token)
+	if i := strings.LastIndex(token, "@"); i >= 0 {
+		return token[:i], token[i+1:]
+	}
+	return "", token
+}
+
+// splitReplayScope splits the given scope of a replay token into the name of the test and the number of the ForAll
+// call, which is zero if the scope does not contain it. Call numbers never start with 0, unlike the #01 suffixes
+// which the testing package gives the subtests with duplicate names.
+func splitReplayScope(scope string) (testName string, call int) {
+	if i := strings.LastIndex(scope, "#"); i >= 0 && !strings.HasPrefix(scope[i+1:], "0") {
+		if call, err := strconv.Atoi(scope[i+1:]); err == nil && call > 0 {
+			return scope[:i], call
+		}
+	}
+	return scope, 0
+}
+
+// formatReplayToken formats the token which replays the given iteration of a check with the given seed and size,
+// prefixed with the name of the test and the number of the ForAll call which the check was run by, if any.
+func formatReplayToken(testName string, call int, seed int64, iteration, size int) string {
+	token := fmt.Sprintf("%d:%d:%d", seed, iteration, size)
+	if testName != "" {
+		token = fmt.Sprintf("%s#%d@%s", testName, call, token)
+	}
+	return token
+}
+
+// parseReplayToken parses the given replay token, which is either a seed, a seed and an iteration,
+// or a seed, an iteration and a size, optionally prefixed with the scope it was printed in (which is ignored).
+// The returned iteration and size are -1 if the token does not contain them.
+func parseReplayToken(token string) (seed int64, iteration, size int, err error) {
+	_, rest := splitReplayToken(token)
+	parts := strings.Split(rest, ":")
+	if len(parts) > 3 {
+		return 0, 0, 0, fmt.Errorf("invalid replay token %q", token)
+	}
+	if seed, err = strconv.ParseInt(parts[0], 10, 64); err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid replay token %q: %w", token, err)
+	}
+	iteration, size = -1, -1
+	if len(parts) > 1 {
+		if iteration, err = strconv.Atoi(parts[1]); err != nil || iteration < 0 {
+			return 0, 0, 0, fmt.Errorf("invalid iteration in replay token %q", token)
+		}
+	}
+	if len(parts) > 2 {
+		if size, err = strconv.Atoi(parts[2]); err != nil || size < 0 {
+			return 0, 0, 0, fmt.Errorf("invalid size in replay token %q", token)
+		}
+	}
+	return seed, iteration, size, nil
+}
+
+// iterationSeed derives the seed of each iteration from the seed of the check (using splitmix64),
+// so that any iteration can be replayed without replaying the ones before it.
+func iterationSeed(seed int64, iteration int) int64 {
+	z := uint64(seed) + uint64(iteration+1)*0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return int64(z ^ (z >> 31))
+}
@@ -0,0 +1,140 @@
+package gen
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func replayableFailure(t *testing.T, options ...Option) *CheckError {
+	t.Helper()
+	g := Map2(Between(0, 1000), StringGen("ab", 0, 10), func(n int, s string) TestPerson {
+		return TestPerson{Name: s, Age: n}
+	})
+	err := Check(g, func(p TestPerson) bool { return p.Age%10 != 7 }, options...)
+
+	var ce *CheckError
+	if !errors.As(err, &ce) {
+		t.Fatalf("expected a CheckError, got: %v", err)
+	}
+	return ce
+}
+
+func TestReplayTokenReproducesTheFailingIteration(t *testing.T) {
+	original := replayableFailure(t)
+	replayed := replayableFailure(t, Replay(original.Token), WithSeed(original.Seed+1))
+
+	if replayed.Iteration != original.Iteration || replayed.Original != original.Original {
+		t.Errorf("replay token %s did not reproduce the failing iteration, expected: %d (%v), got: %d (%v)",
+			original.Token, original.Iteration, original.Original, replayed.Iteration, replayed.Original)
+	}
+}
+
+func TestReplayTokenFromEnvironment(t *testing.T) {
+	original := replayableFailure(t)
+	t.Setenv(replayEnv, original.Token)
+	replayed := replayableFailure(t)
+
+	if replayed.Token != original.Token || replayed.Original != original.Original {
+		t.Errorf("%s did not reproduce the failing iteration, expected: %s, got: %s", replayEnv, original.Token, replayed.Token)
+	}
+}
+
+func TestReplayWithSeedOnly(t *testing.T) {
+	original := replayableFailure(t)
+	replayed := replayableFailure(t, Replay(strconv.FormatInt(original.Seed, 10)))
+
+	if replayed.Token != original.Token {
+		t.Errorf("seed-only replay token did not reproduce the check, expected: %s, got: %s", original.Token, replayed.Token)
+	}
+}
+
+func TestInvalidReplayToken(t *testing.T) {
+	for _, token := range []string{"abc", "12:x", "12:-1"} {
+		if err := Check(Only(1), func(int) bool { return true }, Replay(token)); err == nil {
+			t.Errorf("invalid replay token %q was accepted", token)
+		}
+	}
+}
+
+func TestReplayTokenIsScopedToItsTest(t *testing.T) {
+	g := Between(0, 1000)
+	property := func(n int) bool { return n%10 != 7 }
+
+	ft := &fakeT{name: "TestFailing"}
+	ForAll(ft, g, property, CorpusDir(""))
+	var token string
+	for _, line := range strings.Split(ft.message, "\n") {
+		if strings.HasPrefix(line, "replay with: -gen.seed=") {
+			token = strings.Fields(strings.TrimPrefix(line, "replay with: -gen.seed="))[0]
+		}
+	}
+	if !strings.HasPrefix(token, "TestFailing#1@") {
+		t.Fatalf("the replay token of ForAll is not prefixed with the name of the test: %q", token)
+	}
+	t.Setenv(replayEnv, token)
+
+	replayed := &fakeT{name: "TestFailing"}
+	ForAll(replayed, g, property, CorpusDir(""))
+	if !strings.Contains(replayed.message, token) {
+		t.Errorf("the replay token was not replayed by its test, message: %s", replayed.message)
+	}
+
+	_, replay := splitReplayToken(token)
+	other := &fakeT{name: "TestOther"}
+	ForAll(other, g, property, CorpusDir(""))
+	if strings.Contains(other.message, replay) {
+		t.Errorf("the replay token of another test was replayed: %s", other.message)
+	}
+	var checkErr *CheckError
+	if errors.As(Check(g, property), &checkErr) && checkErr.Token == replay {
+		t.Errorf("the replay token of a test was replayed by Check: %s", checkErr.Token)
+	}
+}
+
+func TestReplayTokenIsScopedToItsCall(t *testing.T) {
+	g := Between(0, 1000)
+	property := func(n int) bool { return n%10 != 7 }
+	tokenOf := func(ft *fakeT) string {
+		for _, line := range strings.Split(ft.message, "\n") {
+			if strings.HasPrefix(line, "replay with: -gen.seed=") {
+				return strings.Fields(strings.TrimPrefix(line, "replay with: -gen.seed="))[0]
+			}
+		}
+		return ""
+	}
+
+	ft := &fakeT{name: "TestCalls"}
+	ForAll(ft, g, func(int) bool { return true }, CorpusDir(""))
+	ForAll(ft, g, property, CorpusDir(""))
+	token := tokenOf(ft)
+	if !strings.HasPrefix(token, "TestCalls#2@") {
+		t.Fatalf("the replay token of ForAll is not prefixed with the number of the call: %q", token)
+	}
+	t.Setenv(replayEnv, token)
+
+	first, second := &fakeT{name: "TestCalls"}, &fakeT{name: "TestCalls"}
+	ForAll(first, g, property, CorpusDir(""))
+	ForAll(second, g, property, CorpusDir(""))
+	ForAll(second, g, property, CorpusDir(""))
+	if first.failed && tokenOf(first) == token {
+		t.Errorf("the replay token of the second call was replayed by the first one")
+	}
+	if tokenOf(second) != token {
+		t.Errorf("the replay token was not replayed by its call, message: %s", second.message)
+	}
+	if name, call := splitReplayScope("TestCalls/case#01"); name != "TestCalls/case#01" || call != 0 {
+		t.Errorf("the suffix of a subtest name was taken for a call number: %q, %d", name, call)
+	}
+}
+
+func TestReplayReportsDiscardedIterations(t *testing.T) {
+	g := Filter(Between(0, 100), func(n int) bool { return n < 0 })
+	err := Check(g, func(int) bool { return true }, Replay("42:3:10"))
+
+	var filterErr *FilterError
+	if err == nil || !errors.As(err, &filterErr) || !strings.Contains(err.Error(), "did not reproduce") {
+		t.Errorf("the discarded replayed iteration was not reported, got: %v", err)
+	}
+}
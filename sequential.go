@@ -1,12 +1,18 @@
 package gen
 
-import "time"
+import (
+	"sync"
+	"time"
+)
 
 type seq[T Numeric] struct {
 	from, to, step, current T
+	lock                    sync.Mutex
 }
 
 func (s *seq[T]) Generate() T {
+	s.lock.Lock()
+	defer s.lock.Unlock()
 	current := s.current
 	if current+s.step > s.to {
 		s.current = s.from + (s.step - (s.to - current) - 1)
@@ -18,9 +24,10 @@ func (s *seq[T]) Generate() T {
 
 // Sequential is a sequential generator that holds the current state of the generator.
 // It will generate numerics, between `from` and `to` (inclusive), with the given `step` size.
+// It's safe for concurrent use, each value is generated exactly once by one of the goroutines using it.
 func Sequential[T Numeric](from, to, step T) Gen[T] {
 	if (from > to && step < 0) || (from < to && step > 0) {
-		return &seq[T]{from, to, step, from}
+		return &seq[T]{from: from, to: to, step: step, current: from}
 	}
 
 	// `from` equals `to` or `step` is zero
@@ -31,9 +38,12 @@ func Sequential[T Numeric](from, to, step T) Gen[T] {
 type timeSeq struct {
 	from, to, current time.Time
 	step              time.Duration
+	lock              sync.Mutex
 }
 
 func (ts *timeSeq) Generate() time.Time {
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
 	current := ts.current
 	if current.Add(ts.step).After(ts.to) {
 		ts.current = ts.from.Add(ts.step - (ts.to.Sub(current)))
@@ -44,9 +54,10 @@ func (ts *timeSeq) Generate() time.Time {
 }
 
 // TimeSeq is a sequential time generator, it generates `time.Time`s within the given range and step.
+// Just like Sequential, it's safe for concurrent use.
 func TimeSeq(from, to time.Time, step time.Duration) Gen[time.Time] {
 	if (from.After(to) && step < 0) || (from.Before(to) && step > 0) {
-		return &timeSeq{from, to, from, step}
+		return &timeSeq{from: from, to: to, current: from, step: step}
 	}
 
 	// `from` equals `to` or `step` is zero
@@ -0,0 +1,487 @@
+package gen
+
+import (
+	"math"
+	"reflect"
+)
+
+// Shrinker describes how to simplify a value of type `T`.
+// Given a value, it returns candidates which are "smaller" than it, the most aggressive ones first.
+// Generators which implement Shrinker for their own type are shrunk automatically by the property runner.
+type Shrinker[T any] interface {
+	Shrink(value T) []T
+}
+
+// ShrinkFunc is the function adapter for Shrinker.
+type ShrinkFunc[T any] func(value T) []T
+
+func (f ShrinkFunc[T]) Shrink(value T) []T { return f(value) }
+
+type shrinkable[T any] struct {
+	underlying Gen[T]
+	shrinker   Shrinker[T]
+}
+
+func (s *shrinkable[T]) Generate() T { return s.underlying.Generate() }
+
+func (s *shrinkable[T]) generate(r *Rand) T { return GenerateWith(s.underlying, r) }
+
+func (s *shrinkable[T]) Shrink(value T) []T { return s.shrinker.Shrink(value) }
+
+// WithShrinker attaches the given shrinker to the generator, so that the property runner can use it
+// to find smaller counterexamples among the values that the generator generates.
+func WithShrinker[T any](g Gen[T], shrinker Shrinker[T]) Gen[T] {
+	return &shrinkable[T]{g, shrinker}
+}
+
+// shrinkerOf returns the Shrinker of the given generator, if it has any.
+func shrinkerOf[T any](g Gen[T]) (Shrinker[T], bool) {
+	s, ok := g.(Shrinker[T])
+	return s, ok
+}
+
+var defaultMaxShrinks = 1000
+
+// shrink repeatedly replaces the failing value with the first of its shrink candidates which still fails the property,
+// until none of the candidates fail, or maxShrinks successful shrinks are made.
+func shrink[T any, P Property[T]](s Shrinker[T], property P, value T, err error, maxShrinks int) (T, error, int) {
+	shrinks := 0
+	for shrinks < maxShrinks {
+		shrunk := false
+		for _, candidate := range s.Shrink(value) {
+			if candidateErr := evaluate[T](property, candidate); candidateErr != nil {
+				value, err = candidate, candidateErr
+				shrunk = true
+				shrinks++
+				break
+			}
+		}
+		if !shrunk {
+			break
+		}
+	}
+	return value, err, shrinks
+}
+
+// simplerChoices reports whether the sequence of choices a is simpler than b, meaning that it's either shorter,
+// or it has the same length and is lexicographically smaller.
+func simplerChoices(a, b []uint64) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+// shrinkChoices shrinks the failing value by simplifying the sequence of random choices it was generated from,
+// and replaying the simplified sequences using the generator. Since the generators composed using Map, FlatMap and MapN
+// draw their choices from the same Rand, this shrinks composed values without the need of a Shrinker.
+// The choices are simplified by deleting chunks of them, zeroing chunks of them, decrementing a choice while deleting
+// the ones following it, and minimizing each choice.
+func shrinkChoices[T any, P Property[T]](
+	g Gen[T], property P, choices []uint64, size int, value T, err error, maxShrinks int,
+) (T, error, int) {
+	shrinks, attempts := 0, 0
+	try := func(candidate []uint64) bool {
+		if shrinks >= maxShrinks || attempts >= 10*maxShrinks {
+			return false
+		}
+		attempts++
+		r := replayingRand(candidate, size)
+		candidateValue, genErr := tryGenerate(g, r)
+		if genErr != nil || !simplerChoices(r.choices, choices) {
+			return false
+		}
+		if candidateErr := evaluate[T](property, candidateValue); candidateErr != nil {
+			choices, value, err = r.choices, candidateValue, candidateErr
+			shrinks++
+			return true
+		}
+		return false
+	}
+	without := func(i, k int) []uint64 {
+		candidate := make([]uint64, 0, len(choices)-k)
+		candidate = append(candidate, choices[:i]...)
+		return append(candidate, choices[i+k:]...)
+	}
+	withZeros := func(i, k int) []uint64 {
+		candidate := make([]uint64, len(choices))
+		copy(candidate, choices)
+		for j := i; j < i+k; j++ {
+			candidate[j] = 0
+		}
+		return candidate
+	}
+	withChoice := func(i int, choice uint64) []uint64 {
+		candidate := withZeros(i, 0)
+		candidate[i] = choice
+		return candidate
+	}
+
+	for improved := true; improved; {
+		improved = false
+		for k := 8; k > 0; k /= 2 {
+			for i := len(choices) - k; i >= 0; i-- {
+				if i+k <= len(choices) && try(without(i, k)) {
+					improved = true
+				}
+			}
+		}
+		for k := 8; k > 0; k /= 2 {
+			for i := len(choices) - k; i >= 0; i-- {
+				if i+k <= len(choices) && try(withZeros(i, k)) {
+					improved = true
+				}
+			}
+		}
+		for i := 0; i < len(choices); i++ {
+			// Choices that determine the size of a collection need the choices of an element to be deleted along with them.
+			for k := 1; k <= 8 && i < len(choices) && choices[i] > 0; k *= 2 {
+				if i+1+k <= len(choices) {
+					candidate := without(i+1, k)
+					candidate[i]--
+					if try(candidate) {
+						improved = true
+					}
+				}
+			}
+		}
+		for i := 0; i < len(choices); i++ {
+			// Binary search for the smallest choice which still fails the property.
+			lo, hi := uint64(0), choices[i]
+			for lo < hi && i < len(choices) {
+				mid := lo + (hi-lo)/2
+				if try(withChoice(i, mid)) {
+					improved = true
+					if i < len(choices) {
+						hi = choices[i]
+					}
+				} else {
+					lo = mid + 1
+				}
+			}
+		}
+	}
+	return value, err, shrinks
+}
+
+// shrinkNumericTowards returns values between target and value, starting from target and halving the distance each time.
+// target must be either zero or have the same sign as value, so that the distance never overflows.
+func shrinkNumericTowards[T Numeric](value, target T) []T {
+	if value == target || value != value {
+		return nil
+	}
+	if f := float64(value); math.IsInf(f, 0) {
+		return []T{target}
+	}
+	candidates := []T{target}
+	diff := value - target
+	for i := 0; i < 64; i++ {
+		diff /= 2
+		candidate := value - diff
+		if diff == 0 || candidate == value {
+			break
+		}
+		if candidate != candidates[len(candidates)-1] {
+			candidates = append(candidates, candidate)
+		}
+	}
+	return candidates
+}
+
+// NumericShrinker shrinks numeric values towards zero.
+func NumericShrinker[T Numeric]() Shrinker[T] {
+	return ShrinkFunc[T](func(value T) []T { return shrinkNumericTowards(value, 0) })
+}
+
+func (r *between[T]) Shrink(value T) []T {
+	// Shrink towards the value which is closest to zero in the range.
+	target := T(0)
+	if r.min > target {
+		target = r.min
+	} else if r.max < target {
+		target = r.max
+	}
+	return shrinkNumericTowards(value, target)
+}
+
+// shrinkLength returns the lengths which a collection of length n can be shrunk into, without going below minLength.
+func shrinkLength(n, minLength int) []int {
+	if n <= minLength {
+		return nil
+	}
+	lengths := []int{minLength}
+	for l := (n + minLength) / 2; l < n; l = (l + n + 1) / 2 {
+		if l != lengths[len(lengths)-1] {
+			lengths = append(lengths, l)
+		}
+	}
+	return lengths
+}
+
+// shrinkSlice removes elements from the given slice (keeping at least minLength of them),
+// and then shrinks each of the remaining elements using the element shrinker, if any.
+func shrinkSlice[T any](value []T, minLength int, elem func(T) []T) [][]T {
+	var candidates [][]T
+	for _, l := range shrinkLength(len(value), minLength) {
+		// Both the prefix and the suffix of the given length
+		candidates = append(candidates, value[:l], value[len(value)-l:])
+	}
+	if len(value) > minLength {
+		for i := range value {
+			candidate := make([]T, 0, len(value)-1)
+			candidate = append(candidate, value[:i]...)
+			candidates = append(candidates, append(candidate, value[i+1:]...))
+		}
+	}
+	if elem != nil {
+		for i, e := range value {
+			for _, shrunkElem := range elem(e) {
+				candidate := make([]T, len(value))
+				copy(candidate, value)
+				candidate[i] = shrunkElem
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+	return candidates
+}
+
+// SliceShrinker shrinks slices by removing their elements, and then by shrinking the remaining elements with
+// the given element shrinker. elem can be nil, in which case only the length of the slices is shrunk.
+func SliceShrinker[T any](elem Shrinker[T]) Shrinker[[]T] {
+	var shrinkElem func(T) []T
+	if elem != nil {
+		shrinkElem = elem.Shrink
+	}
+	return ShrinkFunc[[]T](func(value []T) [][]T { return shrinkSlice(value, 0, shrinkElem) })
+}
+
+// MapShrinker shrinks maps by removing their keys, and then by shrinking the values with the given value shrinker.
+// value can be nil, in which case only the keys are removed.
+func MapShrinker[K comparable, V any](value Shrinker[V]) Shrinker[map[K]V] {
+	return ShrinkFunc[map[K]V](func(m map[K]V) []map[K]V {
+		var candidates []map[K]V
+		if len(m) > 0 {
+			candidates = append(candidates, map[K]V{})
+		}
+		for key := range m {
+			candidate := make(map[K]V, len(m)-1)
+			for k, v := range m {
+				if k != key {
+					candidate[k] = v
+				}
+			}
+			candidates = append(candidates, candidate)
+		}
+		if value != nil {
+			for key, v := range m {
+				for _, shrunk := range value.Shrink(v) {
+					candidate := make(map[K]V, len(m))
+					for k, v := range m {
+						candidate[k] = v
+					}
+					candidate[key] = shrunk
+					candidates = append(candidates, candidate)
+				}
+			}
+		}
+		return candidates
+	})
+}
+
+// PointerShrinker shrinks pointers to nil, and then shrinks the values they point to with the given shrinker.
+// elem can be nil, in which case the pointers are only shrunk to nil.
+func PointerShrinker[T any](elem Shrinker[T]) Shrinker[*T] {
+	return ShrinkFunc[*T](func(value *T) []*T {
+		if value == nil {
+			return nil
+		}
+		candidates := []*T{nil}
+		if elem != nil {
+			for _, shrunk := range elem.Shrink(*value) {
+				shrunk := shrunk
+				candidates = append(candidates, &shrunk)
+			}
+		}
+		return candidates
+	})
+}
+
+func (s *stringGen) Shrink(value string) []string {
+	var simplest func(rune) []rune
+	if s.alphabet.total > 0 {
+		first := s.alphabet.ranges[0]
+		simplest = func(r rune) []rune {
+			if r == first {
+				return nil
+			}
+			return []rune{first}
+		}
+	}
+	candidates := shrinkSlice([]rune(value), s.minLength, simplest)
+	result := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		result[i] = string(candidate)
+	}
+	return result
+}
+
+// shrinkValue is the reflective shrinker of the values which sizedValue can generate,
+// the node holds the generators which are bound to the paths of the fields within the value (if any).
+func (c *inferConfig) shrinkValue(v reflect.Value, node *fieldNode) []reflect.Value {
+	t := v.Type()
+	if wrapped, found := c.generatorsByType[t]; found {
+		if wrapped.shrink == nil {
+			return nil
+		}
+		return wrapped.shrink(v)
+	}
+	var candidates []reflect.Value
+	add := func(set func(reflect.Value)) {
+		candidate := reflect.New(t).Elem()
+		set(candidate)
+		candidates = append(candidates, candidate)
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			add(func(c reflect.Value) { c.SetBool(false) })
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		for _, i := range shrinkNumericTowards(v.Int(), 0) {
+			i := i
+			add(func(c reflect.Value) { c.SetInt(i) })
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		for _, u := range shrinkNumericTowards(v.Uint(), 0) {
+			u := u
+			add(func(c reflect.Value) { c.SetUint(u) })
+		}
+	case reflect.Float32, reflect.Float64:
+		for _, f := range shrinkNumericTowards(v.Float(), 0) {
+			f := f
+			add(func(c reflect.Value) { c.SetFloat(f) })
+		}
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		for _, re := range shrinkNumericTowards(real(c), 0) {
+			re := re
+			add(func(v reflect.Value) { v.SetComplex(complex(re, imag(c))) })
+		}
+		for _, im := range shrinkNumericTowards(imag(c), 0) {
+			im := im
+			add(func(v reflect.Value) { v.SetComplex(complex(real(c), im)) })
+		}
+	case reflect.String:
+		for _, s := range c.strings.Shrink(v.String()) {
+			s := s
+			add(func(c reflect.Value) { c.SetString(s) })
+		}
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		add(func(c reflect.Value) {})
+		for _, elem := range c.shrinkValue(v.Elem(), node) {
+			elem := elem
+			add(func(c reflect.Value) {
+				c.Set(reflect.New(t.Elem()))
+				c.Elem().Set(elem)
+			})
+		}
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		elems := make([]reflect.Value, v.Len())
+		for i := range elems {
+			elems[i] = v.Index(i)
+		}
+		for _, shrunk := range shrinkSlice(elems, 0, c.shrinkerAt(node)) {
+			shrunk := shrunk
+			add(func(c reflect.Value) {
+				c.Set(reflect.MakeSlice(t, len(shrunk), len(shrunk)))
+				for i, elem := range shrunk {
+					c.Index(i).Set(elem)
+				}
+			})
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			i := i
+			for _, elem := range c.shrinkValue(v.Index(i), node) {
+				elem := elem
+				add(func(c reflect.Value) {
+					c.Set(v)
+					c.Index(i).Set(elem)
+				})
+			}
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		keys := v.MapKeys()
+		copyWithout := func(c reflect.Value, skip int) {
+			c.Set(reflect.MakeMapWithSize(t, len(keys)))
+			for j, key := range keys {
+				if j != skip {
+					c.SetMapIndex(key, v.MapIndex(key))
+				}
+			}
+		}
+		if len(keys) > 0 {
+			add(func(c reflect.Value) { c.Set(reflect.MakeMap(t)) })
+		}
+		for i := range keys {
+			i := i
+			add(func(c reflect.Value) { copyWithout(c, i) })
+		}
+		for i, key := range keys {
+			key := key
+			for _, elem := range c.shrinkValue(v.MapIndex(key), node) {
+				elem := elem
+				add(func(c reflect.Value) {
+					copyWithout(c, i)
+					c.SetMapIndex(key, elem)
+				})
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			shrinker, ok := c.fieldShrinker(t, i, node.child(t.Field(i).Name))
+			if !ok {
+				continue
+			}
+			candidates = append(candidates, shrinkField(v, i, shrinker)...)
+		}
+	}
+	return candidates
+}
+
+// shrinkerAt returns the reflective shrinker of the values at the given node.
+func (c *inferConfig) shrinkerAt(node *fieldNode) func(reflect.Value) []reflect.Value {
+	return func(v reflect.Value) []reflect.Value { return c.shrinkValue(v, node) }
+}
+
+// shrinkField shrinks the i-th field of the given struct value using the given shrinker,
+// and returns copies of the struct containing the shrunk field. Unexported fields are left as they are.
+func shrinkField(v reflect.Value, i int, shrinker func(reflect.Value) []reflect.Value) []reflect.Value {
+	if !v.Type().Field(i).IsExported() {
+		return nil
+	}
+	var candidates []reflect.Value
+	for _, field := range shrinker(v.Field(i)) {
+		candidate := reflect.New(v.Type()).Elem()
+		candidate.Set(v)
+		candidate.Field(i).Set(field)
+		candidates = append(candidates, candidate)
+	}
+	return candidates
+}
@@ -0,0 +1,148 @@
+package gen
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func counterexampleOf(t *testing.T, err error) any {
+	t.Helper()
+	var ce *CheckError
+	if !errors.As(err, &ce) {
+		t.Fatalf("expected a CheckError, got: %v", err)
+	}
+	return ce.Counterexample
+}
+
+func TestBetweenShrinksToTheSmallestCounterexample(t *testing.T) {
+	err := Check(Between(0, 1000), func(n int) bool { return n < 500 })
+
+	if actual := counterexampleOf(t, err); actual != 500 {
+		t.Errorf("Between was not shrunk to the smallest counterexample, expected: 500, got: %v", actual)
+	}
+}
+
+func TestBetweenShrinksWithinItsRange(t *testing.T) {
+	err := Check(Between(-1000, -10), func(n int) bool { return n > -20 })
+
+	if actual := counterexampleOf(t, err); actual != -20 {
+		t.Errorf("Between was not shrunk within its range, expected: -20, got: %v", actual)
+	}
+}
+
+func TestStringGenShrinking(t *testing.T) {
+	g := StringGen("abc", 0, 40)
+	err := Check(g, func(s string) bool { return !strings.Contains(s, "c") })
+
+	if actual := counterexampleOf(t, err); actual != "c" {
+		t.Errorf("StringGen was not shrunk to the smallest counterexample, expected: c, got: %v", actual)
+	}
+}
+
+func TestSliceShrinker(t *testing.T) {
+	sliceGen := Pure(func() []int { return GenerateN(Between(0, 100), 40) })
+	g := WithShrinker(sliceGen, SliceShrinker(NumericShrinker[int]()))
+
+	err := Check(g, func(s []int) bool { return len(s) < 3 })
+
+	if actual := counterexampleOf(t, err); !reflect.DeepEqual(actual, []int{0, 0, 0}) {
+		t.Errorf("slice was not shrunk to the smallest counterexample, expected: [0 0 0], got: %v", actual)
+	}
+}
+
+func TestMapAndPointerShrinkers(t *testing.T) {
+	m := map[string]int{"a": 10, "b": 20, "c": 30}
+	mapShrinker := MapShrinker[string](NumericShrinker[int]())
+	for _, candidate := range mapShrinker.Shrink(m) {
+		if len(candidate) > len(m) {
+			t.Errorf("map shrinker grew the map: %v", candidate)
+		}
+	}
+
+	n := 42
+	pointerShrinker := PointerShrinker(NumericShrinker[int]())
+	candidates := pointerShrinker.Shrink(&n)
+	if len(candidates) == 0 || candidates[0] != nil {
+		t.Errorf("pointer shrinker did not try nil first: %v", candidates)
+	}
+	if len(pointerShrinker.Shrink(nil)) != 0 {
+		t.Errorf("pointer shrinker tried to shrink a nil pointer")
+	}
+}
+
+func TestInferShrinksFieldsReflectively(t *testing.T) {
+	g, err := Infer[Person]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkErr := Check(g, func(p Person) bool { return p.Age < 100 })
+
+	expected := Person{"", 100}
+	if actual := counterexampleOf(t, checkErr); actual != expected {
+		t.Errorf("inferred generator was not shrunk to the smallest counterexample, expected: %+v, got: %+v", expected, actual)
+	}
+}
+
+func TestInferUsesShrinkersOfWrappedGenerators(t *testing.T) {
+	g, err := Infer[Person](Wrap(OneOf("John", "Jack")), Wrap(Between(10, 90)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkErr := Check(g, func(p Person) bool { return p.Age < 50 })
+
+	actual := counterexampleOf(t, checkErr).(Person)
+	if actual.Age != 50 || (actual.Name != "John" && actual.Name != "Jack") {
+		t.Errorf("inferred generator did not respect the wrapped generators when shrinking, got: %+v", actual)
+	}
+}
+
+func TestMapNShrinksFieldByField(t *testing.T) {
+	g := Map7(
+		OneOf("John", "Jack", "Beth"), OneOf("Smith", "Hart"), Only("https://github.com/AminMal"),
+		OneOf("Scala", "Rust", "Go"), OneOf("USA", "Iran"), Between(16, 79), Between(1, 8),
+		func(name, surname, git, lang, origin string, age, experience int) Programmer {
+			return Programmer{name, surname, git, lang, origin, age, experience}
+		},
+	)
+
+	err := Check(g, func(p Programmer) bool { return p.Age < 30 || p.Experiance < 5 })
+
+	expected := Programmer{"John", "Smith", "https://github.com/AminMal", "Scala", "USA", 30, 5}
+	if actual := counterexampleOf(t, err); actual != expected {
+		t.Errorf("Map7 was not shrunk field by field, expected: %+v, got: %+v", expected, actual)
+	}
+}
+
+func TestFlatMapShrinking(t *testing.T) {
+	g := FlatMap(Between(0, 20), func(n int) Gen[string] {
+		return StringGen("xyz", uint(n), uint(n))
+	})
+
+	err := Check(g, func(s string) bool { return !strings.Contains(s, "z") })
+
+	if actual := counterexampleOf(t, err); actual != "z" {
+		t.Errorf("FlatMap was not shrunk to the smallest counterexample, expected: z, got: %v", actual)
+	}
+}
+
+func TestSignedValuesShrinkTowardsZero(t *testing.T) {
+	type pair struct{ A, B int }
+	g := Map2(ArbitraryInt, Between(-1000, 1000), func(a, b int) pair { return pair{a, b} })
+
+	err := Check(g, func(p pair) bool { return p.B < 500 })
+
+	if actual := counterexampleOf(t, err).(pair); actual.A != 0 || actual.B < 500 || actual.B >= 600 {
+		t.Errorf("signed values were not shrunk towards zero, got: %+v", actual)
+	}
+
+	floats := Map2(Between(-10.0, 10.0), Between(-1000.0, 1000.0), func(a, b float64) [2]float64 { return [2]float64{a, b} })
+	err = Check(floats, func(p [2]float64) bool { return p[1] < 500 })
+
+	if actual := counterexampleOf(t, err).([2]float64); actual[0] != 0 || actual[1] < 500 || actual[1] > 501 {
+		t.Errorf("signed floats were not shrunk towards zero, got: %v", actual)
+	}
+}
@@ -0,0 +1,21 @@
+package gen
+
+// Sized creates a generator which depends on the size of the Rand it generates from.
+// The property runner starts the size from 0 and grows it with each iteration, up to the MaxSize of the check,
+// so the generated values start small and grow; plain Generate uses the default size.
+//
+//	// slices of up to `size` numbers
+//	g := gen.Sized(func(size int) gen.Gen[[]int] {
+//		return gen.Map(gen.Between(0, size), func(n int) []int { return gen.GenerateN(gen.ArbitraryInt, uint(n)) })
+//	})
+func Sized[T any](f func(size int) Gen[T]) Gen[T] {
+	return randPure[T]{func(r *Rand) T { return GenerateWith(f(r.size), r) }}
+}
+
+// Resize makes the given generator generate with the given size, regardless of the size of the Rand it's given.
+func Resize[T any](g Gen[T], size int) Gen[T] {
+	if size < 0 {
+		size = 0
+	}
+	return randPure[T]{func(r *Rand) T { return withSize(r, size, g) }}
+}
@@ -0,0 +1,65 @@
+package gen
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRunnerGrowsTheSize(t *testing.T) {
+	var sizes []int
+	g := Sized(func(size int) Gen[int] { return Only(size) })
+
+	ForAll(t, g, func(size int) bool {
+		sizes = append(sizes, size)
+		return true
+	}, Iterations(11), MaxSize(10))
+
+	expected := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	if !reflect.DeepEqual(sizes, expected) {
+		t.Errorf("runner did not grow the size from 0 up to MaxSize, expected: %v, got: %v", expected, sizes)
+	}
+}
+
+func TestResize(t *testing.T) {
+	g := Resize(Sized(func(size int) Gen[int] { return Only(size) }), 3)
+
+	if size := GenerateWith(g, NewRand(1)); size != 3 {
+		t.Errorf("Resize did not change the size, expected: 3, got: %d", size)
+	}
+	if size := g.Generate(); size != 3 {
+		t.Errorf("Resize did not change the size of the default Rand, expected: 3, got: %d", size)
+	}
+}
+
+func TestStringGenRespectsTheSize(t *testing.T) {
+	small := Resize(StringGen("ab", 2, 40), 0)
+	for _, s := range GenerateN(small, 100) {
+		if len(s) != 2 {
+			t.Fatalf("StringGen did not generate strings of minLength at size 0, got: %q", s)
+		}
+	}
+
+	medium := Resize(StringGen("ab", 0, 100), 5)
+	for _, s := range GenerateN(medium, 100) {
+		if len(s) > 10 {
+			t.Fatalf("StringGen generated a string longer than its size allows: %q", s)
+		}
+	}
+}
+
+func TestInferRespectsTheSize(t *testing.T) {
+	type Bag struct {
+		Items []int
+		Tags  map[string]bool
+	}
+	g, err := Infer[Bag]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, bag := range GenerateN(Resize(g, 0), 100) {
+		if len(bag.Items) != 0 || len(bag.Tags) != 0 {
+			t.Fatalf("Infer generated non-empty collections at size 0: %+v", bag)
+		}
+	}
+}
@@ -0,0 +1,310 @@
+package gen
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// tagKey is the key of the struct tags which drive the generation of the fields by Infer.
+const tagKey = "gen"
+
+// fieldGen generates the values of a struct field, instead of the generator which is inferred for its type.
+// Both functions are given the node of the field, which holds the generators bound to the paths within it.
+type fieldGen struct {
+	generate func(r *Rand, size int, node *fieldNode) reflect.Value
+	// shrink is nil if the field is not to be shrunk reflectively.
+	shrink func(v reflect.Value, node *fieldNode) []reflect.Value
+	// inferred reports whether the values within the field (e.g., the elements of the collections of the len tag)
+	// are inferred, which requires their types to be inferrable.
+	inferred bool
+}
+
+// prepareTags parses the tags of the fields of all the structs within the given type, so that the invalid tags
+// are reported by Infer rather than at generation time.
+func (c *inferConfig) prepareTags(t reflect.Type) error {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return c.prepareTags(t.Elem())
+	case reflect.Map:
+		if err := c.prepareTags(t.Key()); err != nil {
+			return err
+		}
+		return c.prepareTags(t.Elem())
+	case reflect.Func:
+		for i := 0; i < t.NumOut(); i++ {
+			if err := c.prepareTags(t.Out(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		if _, prepared := c.tags[t]; prepared {
+			return nil
+		}
+		fields := make([]*fieldGen, t.NumField())
+		c.tags[t] = fields
+		for i := range fields {
+			field := t.Field(i)
+			if tag, found := field.Tag.Lookup(tagKey); found {
+				g, err := c.parseTag(field.Type, tag)
+				if err != nil {
+					return fmt.Errorf("gen: invalid tag `%s:\"%s\"` of %s.%s: %w", tagKey, tag, t, field.Name, err)
+				}
+				fields[i] = g
+			}
+			if err := c.prepareTags(field.Type); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// tagged returns the generator of the i-th field of the given struct type, if it's tagged.
+func (c *inferConfig) tagged(t reflect.Type, i int) *fieldGen {
+	return c.tags[t][i]
+}
+
+// parseTag parses the given tag of a field of the given type, which is one of:
+//   - "-", which leaves the field as its zero value
+//   - "between=min,max", for numeric fields
+//   - "oneof=a|b|c", for numeric, string and boolean fields
+//   - "regex=pattern", for string fields
+//   - "len=min..max" or "len=n", for string, slice and map fields
+func (c *inferConfig) parseTag(t reflect.Type, tag string) (*fieldGen, error) {
+	if tag == "-" {
+		return &fieldGen{generate: func(*Rand, int, *fieldNode) reflect.Value { return reflect.New(t).Elem() }}, nil
+	}
+	name, arg, found := strings.Cut(tag, "=")
+	if !found {
+		return nil, fmt.Errorf("expected a key=value pair")
+	}
+	switch name {
+	case "between":
+		return parseBetweenTag(t, arg)
+	case "oneof":
+		return parseOneOfTag(t, arg)
+	case "regex":
+		return parseRegexTag(t, arg)
+	case "len":
+		return c.parseLenTag(t, arg)
+	default:
+		return nil, fmt.Errorf("unknown key: %s", name)
+	}
+}
+
+// parseValue parses the given string as a value of the given type.
+func parseValue(t reflect.Type, s string) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(strings.TrimSpace(s), 10, t.Bits())
+		if err != nil {
+			return v, err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(strings.TrimSpace(s), 10, t.Bits())
+		if err != nil {
+			return v, err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(s), t.Bits())
+		if err != nil {
+			return v, err
+		}
+		v.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(s))
+		if err != nil {
+			return v, err
+		}
+		v.SetBool(b)
+	case reflect.String:
+		v.SetString(s)
+	default:
+		return v, fmt.Errorf("unsupported field type: %s", t)
+	}
+	return v, nil
+}
+
+func parseBetweenTag(t reflect.Type, arg string) (*fieldGen, error) {
+	bounds := strings.Split(arg, ",")
+	if len(bounds) != 2 {
+		return nil, fmt.Errorf("expected between=min,max")
+	}
+	min, err := parseValue(t, bounds[0])
+	if err != nil {
+		return nil, err
+	}
+	max, err := parseValue(t, bounds[1])
+	if err != nil {
+		return nil, err
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflectBetween(t, min.Int(), max.Int(), reflect.Value.Int, reflect.Value.SetInt), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return reflectBetween(t, min.Uint(), max.Uint(), reflect.Value.Uint, reflect.Value.SetUint), nil
+	case reflect.Float32, reflect.Float64:
+		return reflectBetween(t, min.Float(), max.Float(), reflect.Value.Float, reflect.Value.SetFloat), nil
+	default:
+		return nil, fmt.Errorf("between is only supported for numeric fields, not %s", t)
+	}
+}
+
+// reflectBetween generates the values of a numeric type within [min, max] using between, getting and setting
+// the values of the type as N (i.e., int64, uint64 or float64).
+func reflectBetween[N Numeric](t reflect.Type, min, max N, get func(reflect.Value) N, set func(reflect.Value, N)) *fieldGen {
+	b := newBetween(numericMin(min, max), numericMax(min, max), false, nil)
+	value := func(n N) reflect.Value {
+		v := reflect.New(t).Elem()
+		set(v, n)
+		return v
+	}
+	return &fieldGen{
+		generate: func(r *Rand, _ int, _ *fieldNode) reflect.Value { return value(b.generate(r)) },
+		shrink: func(v reflect.Value, _ *fieldNode) []reflect.Value {
+			var candidates []reflect.Value
+			for _, n := range b.Shrink(get(v)) {
+				candidates = append(candidates, value(n))
+			}
+			return candidates
+		},
+	}
+}
+
+func parseOneOfTag(t reflect.Type, arg string) (*fieldGen, error) {
+	var values []reflect.Value
+	for _, s := range strings.Split(arg, "|") {
+		v, err := parseValue(t, s)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return &fieldGen{
+		generate: func(r *Rand, _ int, _ *fieldNode) reflect.Value { return values[r.intn(len(values))] },
+		shrink: func(v reflect.Value, _ *fieldNode) []reflect.Value {
+			// Shrink towards the first values.
+			for i, value := range values {
+				if value.Interface() == v.Interface() {
+					return values[:i]
+				}
+			}
+			return nil
+		},
+	}, nil
+}
+
+func parseRegexTag(t reflect.Type, pattern string) (*fieldGen, error) {
+	if t.Kind() != reflect.String {
+		return nil, fmt.Errorf("regex is only supported for string fields, not %s", t)
+	}
+	g, err := Regex(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return &fieldGen{generate: func(r *Rand, _ int, _ *fieldNode) reflect.Value {
+		v := reflect.New(t).Elem()
+		v.SetString(GenerateWith(g, r))
+		return v
+	}}, nil
+}
+
+// parseLength parses a length, or a range of lengths, like "3..10".
+func parseLength(arg string) (int, int, error) {
+	minArg, maxArg, isRange := strings.Cut(arg, "..")
+	if !isRange {
+		maxArg = minArg
+	}
+	min, err := strconv.ParseUint(strings.TrimSpace(minArg), 10, 31)
+	if err != nil {
+		return 0, 0, err
+	}
+	max, err := strconv.ParseUint(strings.TrimSpace(maxArg), 10, 31)
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(numericMin(min, max)), int(numericMax(min, max)), nil
+}
+
+func (c *inferConfig) parseLenTag(t reflect.Type, arg string) (*fieldGen, error) {
+	min, max, err := parseLength(arg)
+	if err != nil {
+		return nil, err
+	}
+	// The shrinker of the type is used, without going below the minimum length.
+	shrink := func(v reflect.Value, node *fieldNode) []reflect.Value {
+		var candidates []reflect.Value
+		for _, candidate := range c.shrinkValue(v, node) {
+			if candidate.Len() >= min {
+				candidates = append(candidates, candidate)
+			}
+		}
+		return candidates
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		// The strings of the given length are made of the runes of the configured alphabet.
+		stringsGen := &stringGen{c.strings.alphabet, min, max}
+		return &fieldGen{
+			generate: func(r *Rand, size int, _ *fieldNode) reflect.Value {
+				v := reflect.New(t).Elem()
+				v.SetString(stringsGen.sized(r, size))
+				return v
+			},
+			shrink: func(v reflect.Value, _ *fieldNode) []reflect.Value {
+				var candidates []reflect.Value
+				for _, s := range stringsGen.Shrink(v.String()) {
+					candidate := reflect.New(t).Elem()
+					candidate.SetString(s)
+					candidates = append(candidates, candidate)
+				}
+				return candidates
+			},
+		}, nil
+	case reflect.Slice:
+		return &fieldGen{
+			generate: func(r *Rand, size int, node *fieldNode) reflect.Value {
+				n := collectionLength(r, min, numericMin(max, min+size))
+				v := reflect.MakeSlice(t, n, n)
+				for i := 0; i < n; i++ {
+					v.Index(i).Set(c.sizedValue(r, t.Elem(), size-n, node))
+				}
+				return v
+			},
+			shrink:   shrink,
+			inferred: true,
+		}, nil
+	case reflect.Map:
+		return &fieldGen{
+			generate: func(r *Rand, size int, node *fieldNode) reflect.Value {
+				n := collectionLength(r, min, numericMin(max, min+size))
+				v := reflect.MakeMapWithSize(t, n)
+				for discards := 0; v.Len() < n; {
+					key := c.sizedValue(r, t.Key(), size, nil)
+					if v.MapIndex(key).IsValid() {
+						if discards++; discards > defaultMaxDiscards {
+							if v.Len() < min {
+								panic(&FilterError{MaxDiscards: defaultMaxDiscards})
+							}
+							break
+						}
+						continue
+					}
+					v.SetMapIndex(key, c.sizedValue(r, t.Elem(), size-n, node))
+				}
+				return v
+			},
+			shrink:   shrink,
+			inferred: true,
+		}, nil
+	default:
+		return nil, fmt.Errorf("len is only supported for string, slice and map fields, not %s", t)
+	}
+}
@@ -7,8 +7,10 @@ type timeBetween struct {
 	durationGen Gen[int64]
 }
 
-func (t timeBetween) Generate() time.Time {
-	newDuration := t.durationGen.Generate()
+func (t timeBetween) Generate() time.Time { return t.generate(defaultRand) }
+
+func (t timeBetween) generate(r *Rand) time.Time {
+	newDuration := GenerateWith(t.durationGen, r)
 	return t.start.Add(time.Duration(newDuration))
 }
 
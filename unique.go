@@ -0,0 +1,83 @@
+package gen
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ExhaustedError is what a unique generator panics with, when it cannot generate a value which it hasn't generated before.
+type ExhaustedError struct {
+	// Generated is the number of unique values generated before the generator got exhausted.
+	Generated int
+	// MaxRetries is the number of times the generator retried generating a new value.
+	MaxRetries int
+}
+
+func (e *ExhaustedError) Error() string {
+	return fmt.Sprintf(
+		"unique generator is exhausted: it generated %d unique values, and could not generate a new one after %d retries",
+		e.Generated, e.MaxRetries,
+	)
+}
+
+type unique[T any, K comparable] struct {
+	underlying Gen[T]
+	key        func(T) K
+	maxRetries int
+
+	lock sync.Mutex
+	seen map[K]struct{}
+}
+
+func (u *unique[T, K]) Generate() T { return u.generate(defaultRand) }
+
+func (u *unique[T, K]) generate(r *Rand) T {
+	if r.replaying {
+		// The shrinker replays simplified choices, whose values are candidates for the failing one rather than new values,
+		// so they're neither checked against the keys seen before, nor remembered.
+		return GenerateWith(u.underlying, r)
+	}
+	for i := 0; i <= u.maxRetries; i++ {
+		value := GenerateWith(u.underlying, r)
+		if u.add(u.key(value)) {
+			return value
+		}
+	}
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	panic(&ExhaustedError{len(u.seen), u.maxRetries})
+}
+
+// add adds the key to the keys seen before, and reports whether it's new.
+func (u *unique[T, K]) add(key K) bool {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	if _, exists := u.seen[key]; exists {
+		return false
+	}
+	u.seen[key] = struct{}{}
+	return true
+}
+
+// UniqueBy creates a generator which never generates two values with the same key, across all of its calls.
+// It's useful for values which must not repeat within a run, like primary keys and emails, or for values of
+// non-comparable types, which can be identified by a comparable key.
+// When the generated value has been generated before, it retries up to maxRetries times, and then panics with
+// an *ExhaustedError, which means that the underlying generator (most probably) cannot generate any new values.
+//
+// It's safe for concurrent use. Note that it keeps track of all the keys it has generated.
+// When the property runner shrinks a failing value, the candidates are generated by the underlying generator
+// without checking them against the keys generated before, so the counterexample may repeat one of the other values.
+//
+//	userGen := gen.UniqueBy(userGen, func(u User) string { return u.Email }, 100)
+func UniqueBy[T any, K comparable](g Gen[T], key func(T) K, maxRetries int) Gen[T] {
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+	return &unique[T, K]{underlying: g, key: key, maxRetries: maxRetries, seen: make(map[K]struct{})}
+}
+
+// Unique creates a generator which never generates the same value twice, see UniqueBy.
+func Unique[T comparable](g Gen[T], maxRetries int) Gen[T] {
+	return UniqueBy(g, func(value T) T { return value }, maxRetries)
+}
@@ -0,0 +1,105 @@
+package gen
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestUnique(t *testing.T) {
+	g := Unique(Between(0, 1000), 1000)
+
+	seen := make(map[int]bool)
+	for _, value := range GenerateN(g, 500) {
+		if seen[value] {
+			t.Fatalf("Unique generated %d more than once", value)
+		}
+		seen[value] = true
+	}
+}
+
+func TestUniqueByKey(t *testing.T) {
+	type User struct {
+		Email string
+		Tags  []string
+	}
+	g := UniqueBy(
+		Map(StringGen("abc", 3, 3), func(name string) User { return User{name + "@example.com", nil} }),
+		func(u User) string { return u.Email },
+		100,
+	)
+
+	seen := make(map[string]bool)
+	for _, user := range GenerateN(g, 27) {
+		if seen[user.Email] {
+			t.Fatalf("UniqueBy generated a user with the email %s more than once", user.Email)
+		}
+		seen[user.Email] = true
+	}
+}
+
+func TestUniqueIsExhausted(t *testing.T) {
+	g := Unique(OneOf("a", "b"), 50)
+	_ = GenerateN(g, 2)
+
+	defer func() {
+		exhausted, ok := recover().(*ExhaustedError)
+		if !ok || exhausted.Generated != 2 {
+			t.Errorf("Unique did not panic with the expected ExhaustedError, got: %v", exhausted)
+		}
+	}()
+	g.Generate()
+}
+
+func TestUniqueAcrossGoroutines(t *testing.T) {
+	g := Unique(Between(0, 100000), 1000)
+
+	var wg sync.WaitGroup
+	results := make([][]int, goroutines)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = GenerateN(g, 200)
+		}(i)
+	}
+	wg.Wait()
+
+	seen := make(map[int]bool)
+	for _, values := range results {
+		for _, value := range values {
+			if seen[value] {
+				t.Fatalf("Unique generated %d more than once across goroutines", value)
+			}
+			seen[value] = true
+		}
+	}
+}
+
+func TestCheckShrinksUniqueValues(t *testing.T) {
+	g := Unique(Between(0, 1000), 100)
+	err := Check(g, func(n int) bool { return n < 500 })
+
+	if actual := counterexampleOf(t, err); actual != 500 {
+		t.Errorf("unique value was not shrunk to the smallest counterexample, expected: 500, got: %v", actual)
+	}
+	if err := Check(g, func(n int) bool { return n <= 1000 }, Iterations(200)); err != nil {
+		t.Errorf("Check failed for a property that holds: %v", err)
+	}
+}
+
+func TestCheckReportsExhaustedUniqueGenerators(t *testing.T) {
+	err := Check(Unique(Between(0, 10), 5), func(int) bool { return true })
+
+	var exhausted *ExhaustedError
+	if !errors.As(err, &exhausted) || exhausted.Generated > 11 {
+		t.Errorf("Check did not return the ExhaustedError of the unique generator, got: %v", err)
+	}
+
+	ft := &fakeT{name: "TestExhausted"}
+	ForAll(ft, Unique(Between(0, 10), 5), func(int) bool { return true }, CorpusDir(""))
+	if !ft.failed || !strings.Contains(ft.message, "unique generator is exhausted") {
+		t.Errorf("ForAll did not fail the test with the ExhaustedError, got: %q", ft.message)
+	}
+}
@@ -6,19 +6,56 @@ import (
 
 // WrappedGen basically wraps `Gen`s to provide a generator that works with `reflect.Value`
 type WrappedGen struct {
-	tpe reflect.Type
-	vg  Gen[reflect.Value]
+	tpe    reflect.Type
+	vg     Gen[reflect.Value]
+	shrink func(reflect.Value) []reflect.Value
+}
+
+func (w *WrappedGen) applyInfer(c *inferConfig) { c.generatorsByType[w.tpe] = w }
+
+type wrappedGens []*WrappedGen
+
+func (gens wrappedGens) applyInfer(c *inferConfig) {
+	for _, g := range gens {
+		g.applyInfer(c)
+	}
+}
+
+// Wrapped turns the given wrapped generators into a single InferOption, for the code which passes a slice of them to Infer
+// (which used to take wrapped generators only), since a []*WrappedGen cannot be passed as a variadic []InferOption:
+//
+//	personGen, err := gen.Infer[Person](gen.Wrapped(gens...))
+func Wrapped(gens ...*WrappedGen) InferOption {
+	return wrappedGens(gens)
 }
 
 type valueGen[T any] struct {
 	underlying Gen[T]
 }
 
-func (t *valueGen[T]) Generate() reflect.Value {
-	return reflect.ValueOf(t.underlying.Generate())
+func (t *valueGen[T]) Generate() reflect.Value { return t.generate(defaultRand) }
+
+func (t *valueGen[T]) generate(s *Rand) reflect.Value {
+	// The value is taken through a pointer, so that it's of type T even if T is an interface (or the value is nil).
+	value := GenerateWith(t.underlying, s)
+	return reflect.ValueOf(&value).Elem()
 }
 
 // Wrap wraps around a `Gen` and returns a *WrappedGen.
+// If the generator is a Shrinker, values generated by the wrapped generator are shrunk using it as well.
 func Wrap[T any](g Gen[T]) *WrappedGen {
-	return &WrappedGen{reflect.TypeOf(*new(T)), &valueGen[T]{g}}
+	wrapped := &WrappedGen{reflect.TypeOf((*T)(nil)).Elem(), &valueGen[T]{g}, nil}
+	if s, ok := shrinkerOf(g); ok {
+		wrapped.shrink = func(v reflect.Value) []reflect.Value {
+			var value T
+			reflect.ValueOf(&value).Elem().Set(v)
+			shrunk := s.Shrink(value)
+			values := make([]reflect.Value, len(shrunk))
+			for i := range shrunk {
+				values[i] = reflect.ValueOf(&shrunk[i]).Elem()
+			}
+			return values
+		}
+	}
+	return wrapped
 }